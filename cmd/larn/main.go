@@ -1,18 +1,27 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
+	"os"
 	"runtime/debug"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/thorfour/larn/pkg/game"
 	"github.com/thorfour/larn/pkg/game/data"
+	"github.com/thorfour/larn/pkg/game/state/monster"
+	larnio "github.com/thorfour/larn/pkg/io"
 )
 
 var (
 	difficulty = flag.Int("d", 0, "sets the game difficulty")
+	monsters   = flag.Bool("monsters", false, "print the monster table and exit")
+	format     = flag.String("format", "text", "format of the monster table, text or json")
+	theme      = flag.String("theme", "", "color theme: classic, color or the path to a json theme file (defaults to theme.json in the larn config directory)")
+	keys       = flag.String("keys", "", "key bindings: vi, arrows, numpad, wasd or the path to a json keymap file (defaults to keys.json in the larn config directory)")
 )
 
 func init() {
@@ -28,9 +37,37 @@ func init() {
 
 func main() {
 	defer flushLogs() // To ensure logs are flushed
-	if err := game.New(&data.Settings{
+
+	if *monsters {
+		if err := printMonsters(os.Stdout, *format); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := setTheme(*theme); err != nil {
+		fmt.Fprintln(os.Stderr, "unable to load theme:", err)
+		os.Exit(1)
+	}
+
+	keymap, err := loadKeymap(*keys)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to load key bindings:", err)
+		os.Exit(1)
+	}
+
+	taxFile, err := data.TaxFile()
+	if err != nil {
+		log.WithField("error", err).Error("unable to locate tax record")
+	}
+
+	g := game.New(&data.Settings{
 		Difficulty: *difficulty,
-	}).Start(); err != nil {
+		TaxFile:    taxFile,
+	})
+	g.SetKeymap(keymap)
+	if err := g.Start(); err != nil {
 		log.WithField("error", err).Fatal("game exited with error")
 	}
 }
@@ -41,3 +78,64 @@ func flushLogs() {
 		log.Error(string(debug.Stack())) // Log the stack trace
 	}
 }
+
+// setTheme loads the named theme, or the user's theme file if no theme is named
+func setTheme(name string) error {
+	if name == "" {
+		path, err := data.ThemeFile()
+		if err != nil {
+			log.WithField("error", err).Error("unable to locate theme file")
+			return nil
+		}
+		if _, err := os.Stat(path); err != nil { // keep the default theme
+			return nil
+		}
+		name = path
+	}
+
+	t, err := larnio.LoadTheme(name)
+	if err != nil {
+		return err
+	}
+	larnio.SetTheme(t)
+	return nil
+}
+
+// loadKeymap loads the named keymap, or the user's keymap file if no keymap is named
+func loadKeymap(name string) (game.Keys, error) {
+	if name == "" {
+		name = "vi"
+		path, err := data.KeymapFile()
+		if err != nil {
+			log.WithField("error", err).Error("unable to locate keymap file")
+		} else if _, err := os.Stat(path); err == nil {
+			name = path
+		}
+	}
+
+	return game.LoadKeymap(name)
+}
+
+// printMonsters writes the full monster table to w in the given format
+func printMonsters(w io.Writer, format string) error {
+	table := monster.Table()
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(table)
+	case "text":
+		for _, e := range table {
+			line := e.String()
+			if e.Notes != "" {
+				line += "  " + e.Notes
+			}
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown monster table format %q", format)
+	}
+}
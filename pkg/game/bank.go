@@ -3,6 +3,7 @@ package game
 import (
 	"bytes"
 	"fmt"
+	"sort"
 	"strconv"
 	"text/tabwriter"
 	"time"
@@ -26,16 +27,20 @@ func bankPage(gold int, stones map[rune]*items.Gem) string {
 	w := tabwriter.NewWriter(buf, 5, 0, 1, ' ', tabwriter.TabIndent)
 	fmt.Fprintln(w, "Gemstone\t\t\tAppraisal\t\tGemstone\t\t\tAppraisal")
 
-	// Display all stones
-	j := 0
-	for r, s := range stones {
+	// Display all stones in inventory order
+	var order []rune
+	for r := range stones {
+		order = append(order, r)
+	}
+	sort.Slice(order, func(i, j int) bool { return order[i] < order[j] })
+	for j, r := range order {
+		s := stones[r]
 		switch j % 2 {
 		case 0:
-			fmt.Fprintf(w, "%s) %s\t\t\t%v\t\t", string(r), s.String(), s.Value)
+			fmt.Fprintf(w, "%s) %s\t\t\t%v\t\t", string(r), s.String(nil), s.Appraisal())
 		case 1:
-			fmt.Fprintf(w, "%s) %s\t\t\t%v\t\t\n", string(r), s.String(), s.Value)
+			fmt.Fprintf(w, "%s) %s\t\t\t%v\t\t\n", string(r), s.String(nil), s.Appraisal())
 		}
-		j++
 	}
 
 	// Pad out the rest
@@ -66,23 +71,20 @@ func whichstone() string {
 func (g *Game) bankHandler() func(termbox.Event) {
 	g.renderSplash(bankPage(int(g.currentState.C.Stats.Gold), g.currentState.C.Gems()))
 	return func(e termbox.Event) {
-		switch e.Key {
-		case termbox.KeyEsc: // Exit
+		switch g.keys.prompt(e) {
+		case Cancel: // Exit
 			g.inputHandler = g.defaultHandler
 			g.render(display(g.currentState))
-		default:
-			switch e.Ch {
-			case 'd': // deposit into bank
-				g.renderSplash(bankPage(int(g.currentState.C.Stats.Gold), g.currentState.C.Gems()) + howmuch())
-				g.inputHandler = g.accountHandler(true)
-			case 'w': // witdraw from the bank
-				g.renderSplash(bankPage(int(g.currentState.C.Stats.Gold), g.currentState.C.Gems()) + howmuch())
-				g.inputHandler = g.accountHandler(false)
-			case 's': // sell a stone
-				stones := g.currentState.C.Gems()
-				g.renderSplash(bankPage(int(g.currentState.C.Stats.Gold), stones) + whichstone())
-				g.inputHandler = g.gemsaleHandler(stones)
-			}
+		case Deposit: // deposit into bank
+			g.renderSplash(bankPage(int(g.currentState.C.Stats.Gold), g.currentState.C.Gems()) + howmuch())
+			g.inputHandler = g.accountHandler(true)
+		case Withdraw: // witdraw from the bank
+			g.renderSplash(bankPage(int(g.currentState.C.Stats.Gold), g.currentState.C.Gems()) + howmuch())
+			g.inputHandler = g.accountHandler(false)
+		case SellGem: // sell a stone
+			stones := g.currentState.C.Gems()
+			g.renderSplash(bankPage(int(g.currentState.C.Stats.Gold), stones) + whichstone())
+			g.inputHandler = g.gemsaleHandler(stones)
 		}
 	}
 }
@@ -90,19 +92,20 @@ func (g *Game) bankHandler() func(termbox.Event) {
 func (g *Game) accountHandler(deposit bool) func(termbox.Event) {
 	var amt string
 	return func(e termbox.Event) {
-		if e.Ch == '*' { // Short circuit for a deposit/withdraw all action
+		a := g.keys.prompt(e)
+		if a == All { // Short circuit for a deposit/withdraw all action
 			if deposit {
 				amt = fmt.Sprintf("%v", g.currentState.C.Stats.Gold)
 			} else {
 				amt = fmt.Sprintf("%v", account)
 			}
-			e.Key = termbox.KeyEnter // To enter the next switch statement to deposit/withdraw
+			a = Confirm // To enter the next switch statement to deposit/withdraw
 		}
-		switch e.Key {
-		case termbox.KeyEsc: // Exit
+		switch a {
+		case Cancel: // Exit
 			g.inputHandler = g.defaultHandler
 			g.render(display(g.currentState))
-		case termbox.KeyEnter: // Deposit/Withdraw
+		case Confirm: // Deposit/Withdraw
 			n, err := strconv.Atoi(amt)
 			if err != nil {
 				log.WithField("amount", amt).Error("unable to convert bank input to number")
@@ -155,70 +158,27 @@ func (g *Game) accountHandler(deposit bool) func(termbox.Event) {
 
 // gemsaleHandler handles selling a gemstone
 func (g *Game) gemsaleHandler(stones map[rune]*items.Gem) func(termbox.Event) {
+	sell := func(r rune) {
+		s := stones[r]
+		delete(stones, r)            // remove the stone from the local stones
+		g.currentState.C.DropItem(r) // remove the stone from the players inventory
+		g.currentState.C.Stats.Gold += uint(s.Appraisal())
+	}
+
 	return func(e termbox.Event) {
-		switch e.Key {
-		case termbox.KeyEsc: // Exit
+		switch g.keys.prompt(e) {
+		case Cancel: // Exit
 			g.inputHandler = g.defaultHandler
 			g.render(display(g.currentState))
-		default:
-			switch e.Ch {
-			case 'a':
-				fallthrough
-			case 'b':
-				fallthrough
-			case 'c':
-				fallthrough
-			case 'd':
-				fallthrough
-			case 'e':
-				fallthrough
-			case 'f':
-				fallthrough
-			case 'g':
-				fallthrough
-			case 'h':
-				fallthrough
-			case 'i':
-				fallthrough
-			case 'j':
-				fallthrough
-			case 'k':
-				fallthrough
-			case 'l':
-				fallthrough
-			case 'm':
-				fallthrough
-			case 'n':
-				fallthrough
-			case 'o':
-				fallthrough
-			case 'p':
-				fallthrough
-			case 'q':
-				fallthrough
-			case 'r':
-				fallthrough
-			case 's':
-				fallthrough
-			case 't':
-				fallthrough
-			case 'u':
-				fallthrough
-			case 'v':
-				fallthrough
-			case 'w':
-				fallthrough
-			case 'x':
-				fallthrough
-			case 'y':
-				fallthrough
-			case 'z':
-				if s, ok := stones[e.Ch]; ok {
-					delete(stones, e.Ch)            // remove the stone from the local stones
-					g.currentState.C.DropItem(e.Ch) // remove the stone from the players inventory
-					g.currentState.C.Stats.Gold += uint(s.Value)
-					g.renderSplash(bankPage(int(g.currentState.C.Stats.Gold), g.currentState.C.Gems()))
-				}
+		case All: // sell all the stones
+			for r := range stones {
+				sell(r)
+			}
+			g.renderSplash(bankPage(int(g.currentState.C.Stats.Gold), g.currentState.C.Gems()))
+		default: // sell the stone in the slot
+			if _, ok := stones[e.Ch]; ok {
+				sell(e.Ch)
+				g.renderSplash(bankPage(int(g.currentState.C.Stats.Gold), g.currentState.C.Gems()))
 			}
 		}
 	}
@@ -136,8 +136,8 @@ func collegePage(gold int) string {
 func (g *Game) collegeHandler() func(termbox.Event) {
 	g.renderSplash(collegePage(int(g.currentState.C.Stats.Gold)))
 	return func(e termbox.Event) {
-		switch e.Key {
-		case termbox.KeyEsc: // Exit
+		switch g.keys.action(e) {
+		case Cancel: // Exit
 			g.inputHandler = g.defaultHandler
 			g.render(display(g.currentState))
 		default:
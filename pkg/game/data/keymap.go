@@ -0,0 +1,15 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// KeymapFile returns the default location of the key bindings for the current user
+func KeymapFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "larn", "keys.json"), nil
+}
@@ -10,4 +10,6 @@ type Settings struct {
 	Difficulty int
 	// FromSaveFile if the current game was loaded from a save file
 	FromSaveFile bool
+	// TaxFile filepath location of the tax record, taxes aren't carried between games if empty
+	TaxFile string
 }
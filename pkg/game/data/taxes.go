@@ -0,0 +1,53 @@
+package data
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// Taxes is the record of taxes owed to the Larn Revenue Service, carried between games
+type Taxes struct {
+	// Owed number of gold pieces owed in taxes
+	Owed int `json:"owed"`
+}
+
+// TaxFile returns the default location of the tax record for the current user
+func TaxFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "larn", "taxes.json"), nil
+}
+
+// LoadTaxes reads the tax record at path. A missing record means nothing is owed
+func LoadTaxes(path string) (*Taxes, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return new(Taxes), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	t := new(Taxes)
+	if err := json.Unmarshal(b, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// SaveTaxes writes the tax record to path
+func SaveTaxes(path string, t *Taxes) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(t)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, b, 0644)
+}
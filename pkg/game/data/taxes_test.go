@@ -0,0 +1,39 @@
+package data
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestTaxes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "larn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "larn", "taxes.json")
+	tax, err := LoadTaxes(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tax.Owed != 0 {
+		t.Error("missing tax record should owe nothing")
+	}
+
+	if err := SaveTaxes(path, &Taxes{Owed: 1500}); err != nil {
+		t.Fatal(err)
+	}
+
+	tax, err = LoadTaxes(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tax.Owed != 1500 {
+		t.Errorf("unexpected taxes owed %v", tax.Owed)
+	}
+}
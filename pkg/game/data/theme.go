@@ -0,0 +1,15 @@
+package data
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// ThemeFile returns the default location of the color theme for the current user
+func ThemeFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "larn", "theme.json"), nil
+}
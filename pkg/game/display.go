@@ -21,7 +21,24 @@ func (s Simple) Bg() termbox.Attribute { return termbox.ColorDefault }
 
 // display returns a 2d slice representation of the game
 func display(s *state.State) [][]io.Runeable {
-	return cat(s.CurrentMap(), infoBarGrid(s), statusLog(s))
+	return cat(effectsPanel(s), infoBarGrid(s), statusLog(s))
+}
+
+// effectsPanel returns the current map with the active effects and their remaining turns listed down the right side
+func effectsPanel(s *state.State) [][]io.Runeable {
+	m := s.CurrentMap()
+	panel := make([][]io.Runeable, len(m))
+	copy(panel, m)
+
+	for i, e := range s.C.Cond.Active() {
+		if i >= len(panel) {
+			break
+		}
+		line := convert([]string{fmt.Sprintf(" %-8s%4d", e.Condition, e.Turns)})[0]
+		panel[i] = append(append([]io.Runeable{}, m[i]...), line...)
+	}
+
+	return panel
 }
 
 // infoBarGrid returns the info bar in display grid format
@@ -2,12 +2,15 @@ package game
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	termbox "github.com/nsf/termbox-go"
 	log "github.com/sirupsen/logrus"
 	"github.com/thorfour/larn/pkg/game/data"
 	"github.com/thorfour/larn/pkg/game/state"
+	"github.com/thorfour/larn/pkg/game/state/character"
 	"github.com/thorfour/larn/pkg/game/state/items"
 	"github.com/thorfour/larn/pkg/game/state/maps"
 	"github.com/thorfour/larn/pkg/game/state/types"
@@ -23,11 +26,13 @@ const (
 	takeOffAction
 	readAction
 	quaffAction
+	eatAction
 )
 
 var (
 	Quit = fmt.Errorf("%s", "Quit")
 	Save = fmt.Errorf("%s", "Save")
+	Won  = fmt.Errorf("%s", "Won")
 )
 
 const (
@@ -49,6 +54,9 @@ type Game struct {
 	// inputHandler is the function that handles input from the keyboard
 	inputHandler func(e termbox.Event)
 
+	// keys maps the keyboard to the actions of the game
+	keys Keys
+
 	// Indicates if the game has hit an error
 	err error
 }
@@ -65,6 +73,7 @@ func New(s *data.Settings) *Game {
 	g := new(Game)
 	g.settings = s
 	g.inputHandler = g.defaultHandler
+	g.keys = Keys{Keymap: viKeys, Prompt: promptKeys}
 	g.input = make(chan termbox.Event, internalKeyBufferSize)
 
 	if ok, saveFile := saveFilePresent(); ok {
@@ -75,10 +84,16 @@ func New(s *data.Settings) *Game {
 
 	// Generate starting game state
 	g.currentState = state.New(g.settings.Difficulty)
+	g.loadTaxes()
 
 	return g
 }
 
+// SetKeymap sets the keys used to play the game
+func (g *Game) SetKeymap(k Keys) {
+	g.keys = k
+}
+
 // Start is the entrypoint to running a new game, should not return without a request from the user
 func (g *Game) Start() error {
 	if err := termbox.Init(); err != nil {
@@ -109,7 +124,7 @@ func (g *Game) run() error {
 	for {
 		// Check for a game error
 		if g.err != nil {
-			if g.err == Save || g.err == Quit { // Save or Quit aren't errors to return
+			if g.err == Save || g.err == Quit || g.err == Won { // Save, Quit or Won aren't errors to return
 				return nil
 			}
 			return g.err
@@ -125,6 +140,12 @@ func (g *Game) run() error {
 
 		// Handle the next input event
 		g.inputHandler(e)
+
+		// Pause on anything logged that didn't fit in the status log
+		if msgs := g.currentState.Unread(); len(msgs) > logLength {
+			g.inputHandler = g.more(msgs, g.inputHandler)
+		}
+		g.currentState.MarkRead()
 	}
 }
 
@@ -133,88 +154,117 @@ func (g *Game) defaultWrapper() func(termbox.Event) {
 }
 
 func (g *Game) defaultHandler(e termbox.Event) {
-
-	switch e.Ch {
-	case 'H': // run left
-		g.runAction(types.Left)
-	case 'J': // run down
-		g.runAction(types.Down)
-	case 'K': // run up
-		g.runAction(types.Up)
-	case 'L': // run right
-		g.runAction(types.Right)
-	case 'Y': // run northwest
-		g.runAction(types.UpLeft)
-	case 'U': // run northeast
-		g.runAction(types.UpRight)
-	case 'B': // run southwest
-		g.runAction(types.DownLeft)
-	case 'N': // run southeast
-		g.runAction(types.DownRight)
-	case 'h': // move left
-		g.currentState.Move(types.Left)
-		g.render(display(g.currentState))
-	case 'j': // move down
-		g.currentState.Move(types.Down)
-		g.render(display(g.currentState))
-	case 'k': // move up
-		g.currentState.Move(types.Up)
-		g.render(display(g.currentState))
-	case 'l': // move right
-		g.currentState.Move(types.Right)
-		g.render(display(g.currentState))
-	case 'y': // move northwest
-		g.currentState.Move(types.UpLeft)
+	a := g.keys.action(e)
+	if d, ok := moves[a]; ok {
+		g.currentState.Move(d)
 		g.render(display(g.currentState))
-	case 'u': // move northeast
-		g.currentState.Move(types.UpRight)
-		g.render(display(g.currentState))
-	case 'b': // move southwest
-		g.currentState.Move(types.DownLeft)
-		g.render(display(g.currentState))
-	case 'n': // move southeast
-		g.currentState.Move(types.DownRight)
-		g.render(display(g.currentState))
-	case ',': // Pick up the item
+		return
+	}
+	if d, ok := runs[a]; ok {
+		g.runAction(d)
+		return
+	}
+
+	switch a {
+	case PickUp: // Pick up the item
 		g.currentState.PickUp()
 		g.render(display(g.currentState))
-	case '^': // identify a trap
-		g.currentState.IdentTrap()
+	case Search: // search for traps
+		g.currentState.Search()
 		g.render(display(g.currentState))
-	case 'd': // drop an item
+	case Drop: // drop an item
 		g.inputHandler = g.itemAction(dropAction)
-	case 'v': // print program version
-	case '?': // help screen
+	case Version: // print program version
+	case Help: // help screen
 		g.inputHandler = g.help()
-	case 'g': // give present pack weight
-	case 'i': // inventory your pockets
+	case Weight: // give present pack weight
+		g.currentState.Log(g.currentState.PackWeight())
+		g.render(display(g.currentState))
+	case Inventory: // inventory your pockets
 		g.inputHandler = g.inventoryWrapper(g.defaultWrapper)
-	case 'A': // create diagnostic file
-	case '.': // stay here
-	case 'Z': // teleport yourself
-	case 'c': // cast a spell
+	case Diagnostic: // create diagnostic file
+	case Travel: // travel somewhere
+		g.inputHandler = g.travelAction()
+	case Look: // look at something
+		g.inputHandler = g.lookAction()
+	case Explore: // explore the level
+		if _, err := g.currentState.Explore(); err != nil {
+			g.currentState.Log(err.Error())
+		}
+		g.render(display(g.currentState))
+	case Rest: // stay here
+		g.currentState.Rest(1)
+		g.render(display(g.currentState))
+	case RestUntilHealed: // rest until healed
+		g.currentState.RestUntilHealed()
+		g.render(display(g.currentState))
+	case Teleport: // teleport yourself
+	case Cast: // cast a spell
 		g.inputHandler = g.cast()
-	case 'r': // read a scroll/book
+	case Read: // read a scroll/book
 		g.inputHandler = g.itemAction(readAction)
-	case 'q': // quaff a potion
+	case Quaff: // quaff a potion
 		g.inputHandler = g.itemAction(quaffAction)
-	case 'W': // wear armor
+	case Wear: // wear armor
 		g.inputHandler = g.itemAction(wearAction)
-	case 'T': // take off armor
+	case TakeOff: // take off armor
 		g.inputHandler = g.itemAction(takeOffAction)
-	case 'w': // wield a weapon
+	case Wield: // wield a weapon
 		g.inputHandler = g.itemAction(wieldAction)
-	case 'P': // give tax status
-	case 'D': // list all items found
-	case 'e': // eat something
-	case 'S': // save the game and quit
+	case Taxes: // give tax status
+		g.currentState.Log(strings.TrimSpace(tax(g.currentState.Taxes)))
+		g.render(display(g.currentState))
+	case Discoveries: // list all items found
+		g.inputHandler = g.pagedWrapper(g.currentState.Discoveries(), g.defaultWrapper)
+	case History: // message history
+		g.inputHandler = g.historyAction()
+	case Bestiary: // list all monsters encountered
+		g.inputHandler = g.pagedWrapper(g.currentState.Bestiary(), g.defaultWrapper)
+	case Eat: // eat something
+		g.inputHandler = g.itemAction(eatAction)
+	case SaveGame: // save the game and quit
 		g.err = Save
 		return
-	case 'Q': // quit the game
+	case QuitGame: // quit the game
 		g.err = Quit // Set the error to quit
 		return
-	case 'E': // Enter the building
+	case Enter: // Enter the building
 		g.inputHandler = g.enterAction()
+	case Pray: // pray at an altar
+		if err := g.currentState.Pray(); err != nil {
+			g.currentState.Log(err.Error())
+		}
+		g.render(display(g.currentState))
+	case None:
+		if e.Ch >= '0' && e.Ch <= '9' { // count for resting
+			g.inputHandler = g.countHandler(e.Ch)
+		}
+	}
+}
+
+// countHandler collects a count typed before a command. A count followed by . rests that many turns
+func (g *Game) countHandler(first rune) func(termbox.Event) {
+	const prompt = "Count: "
+	count := string(first)
+	g.currentState.Log(prompt + count)
+	g.render(display(g.currentState))
+
+	return func(e termbox.Event) {
+		switch {
+		case e.Ch >= '0' && e.Ch <= '9':
+			count += string(e.Ch)
+			g.currentState.LogReplace(prompt + count)
+			g.render(display(g.currentState))
+			return
+		case g.keys.action(e) == Rest:
+			n, _ := strconv.Atoi(count)
+			g.currentState.LogReplace(fmt.Sprintf("You rest for %v turns", n))
+			g.currentState.Rest(n)
+		default:
+			g.currentState.LogReplace("aborted")
+		}
+		g.inputHandler = g.defaultHandler
+		g.render(display(g.currentState))
 	}
 }
 
@@ -242,17 +292,54 @@ func (g *Game) renderCharacter(c types.Coordinate) {
 	g.err = io.RenderCell(c.X, c.Y, '&', termbox.ColorGreen, termbox.ColorGreen)
 }
 
+// renderCursor highlights the cell under the targeting cursor
+func (g *Game) renderCursor(c types.Coordinate) {
+	if g.err != nil {
+		return
+	}
+
+	r := g.currentState.CurrentMap()[c.Y][c.X].Rune()
+	g.err = io.RenderCellAs(c.X, c.Y, r, io.Cursor)
+}
+
 func (g *Game) runAction(d types.Direction) {
-	for moved := g.currentState.Move(d); moved; moved = g.currentState.Move(d) {
-		g.render(display(g.currentState))
+	g.currentState.Run(d)
+	g.render(display(g.currentState))
+}
+
+// travelAction offers the known places on the level and walks the player to the chosen one
+func (g *Game) travelAction() func(termbox.Event) {
+	targets := g.currentState.TravelTargets()
+	if len(targets) > 'z'-'a'+1 {
+		targets = targets[:'z'-'a'+1]
+	}
+
+	list := make([]string, 0, len(targets))
+	for i, t := range targets {
+		list = append(list, fmt.Sprintf("%c) %s", 'a'+i, t.Name))
 	}
+
+	return g.selectMenu(menu{
+		title: "Where do you want to go?",
+		empty: "You don't know of anywhere to go",
+		list:  list,
+		done: func(choice []rune) {
+			if _, err := g.currentState.Travel(targets[choice[0]-'a'].Coord); err != nil {
+				g.currentState.Log(err.Error())
+			}
+		},
+	})
 }
 
 // inventoryWrapper returns a truncated input handler, used after a user requests an inventory display
 // it will render the first inventory list, and subsequent calls the the function it returns will render the remaining pages
 func (g *Game) inventoryWrapper(callback func() func(termbox.Event)) func(termbox.Event) {
+	return g.pagedWrapper(g.currentState.Inventory(), callback)
+}
+
+// pagedWrapper displays the lines a page at a time over the map, then returns to the callback
+func (g *Game) pagedWrapper(s []string, callback func() func(termbox.Event)) func(termbox.Event) {
 	offset := 0
-	s := g.currentState.Inventory()
 
 	generateInv := func() []string {
 		var inv []string
@@ -269,11 +356,11 @@ func (g *Game) inventoryWrapper(callback func() func(termbox.Event)) func(termbo
 	g.render(overlay(display(g.currentState), convert(generateInv())))
 
 	return func(e termbox.Event) {
-		switch e.Key {
-		case termbox.KeyEsc: // Escape key
+		switch g.keys.action(e) {
+		case Cancel:
 			g.inputHandler = callback()
 			g.render(display(g.currentState))
-		case termbox.KeySpace: // Space key
+		case NextPage:
 			if offset < len(s) { // Render next page
 				g.render(overlay(display(g.currentState), convert(generateInv())))
 				return
@@ -290,86 +377,187 @@ func (g *Game) inventoryWrapper(callback func() func(termbox.Event)) func(termbo
 
 // itemAction is a subroutine for a player to interact with his inventory
 func (g *Game) itemAction(a action) func(termbox.Event) {
+	use := func(choice []rune) {
+		for _, r := range choice {
+			g.useItem(a, r)
+		}
+	}
 
 	switch a {
 	case wieldAction:
-		g.currentState.Log("What do you want to wield (- for nothing) [* for all] ?")
+		return g.selectMenu(menu{
+			title:  "What do you want to wield (- for nothing) ?",
+			empty:  "You don't have anything to wield!",
+			filter: wieldable,
+			keys: map[rune]func(){
+				'-': func() {
+					if err := g.currentState.Disarm(); err != nil {
+						g.currentState.Log(err.Error())
+					}
+				},
+			},
+			done: use,
+		})
 	case dropAction:
-		g.currentState.Log("What do you want to drop [* for all] ?")
+		return g.selectMenu(menu{
+			title: "What do you want to drop (. for gold) ?",
+			empty: "You don't have anything to drop!",
+			multi: true,
+			keys: map[rune]func(){
+				'.': func() { g.inputHandler = g.dropGoldHandler() },
+			},
+			done: g.dropItems,
+		})
 	case wearAction:
-		g.currentState.Log("What do you want to wear [* for all] ?")
+		return g.selectMenu(menu{
+			title:  "What do you want to wear ?",
+			empty:  "You don't have anything to wear!",
+			filter: wearable,
+			done:   use,
+		})
 	case takeOffAction:
-		if err := g.currentState.C.TakeOff(); err != nil {
-			g.currentState.Log("You aren't wearing anything")
+		if err := g.currentState.TakeOff(); err != nil {
+			g.currentState.Log(err.Error())
 		} else {
 			g.currentState.Log("Your armor is off")
 		}
 		g.render(display(g.currentState))
 		return g.defaultHandler
 	case readAction:
-		g.currentState.Log("What do you want to read [* for all] ?")
+		return g.selectMenu(menu{
+			title:  "What do you want to read ?",
+			empty:  "You don't have anything to read!",
+			filter: readable,
+			done:   use,
+		})
 	case quaffAction:
-		g.currentState.Log("What do you want to quaff [space to view] ?")
+		return g.selectMenu(menu{
+			title:  "What do you want to quaff ?",
+			empty:  "You don't have anything to quaff!",
+			filter: quaffable,
+			done:   use,
+		})
+	case eatAction:
+		return g.selectMenu(menu{
+			title:  "What do you want to eat ?",
+			empty:  "You don't have anything to eat!",
+			filter: edible,
+			done:   use,
+		})
 	default:
 		log.WithField("action", a).Fatal("unknown item action")
 	}
 
+	return g.defaultHandler
+}
+
+// useItem performs the item action on the item in slot r
+func (g *Game) useItem(a action, r rune) {
+	var err error
+	switch a {
+	case wieldAction:
+		err = g.currentState.Wield(r)
+	case wearAction:
+		err = g.currentState.Wear(r)
+	case dropAction:
+		g.dropItems([]rune{r})
+	case readAction:
+		err = g.currentState.Read(r)
+	case eatAction:
+		err = g.currentState.Eat(r)
+	case quaffAction:
+		var callback func() bool
+		callback, err = g.currentState.Quaff(r)
+		g.render(display(g.currentState))
+		if callback != nil {
+			for callback() { // render until callback returns false
+				g.render(display(g.currentState))
+			}
+		}
+	}
+	if err != nil {
+		g.currentState.Log(err.Error())
+	}
+}
+
+// dropItems drops every item in the slots as a single action
+func (g *Game) dropItems(slots []rune) {
+	dropped, err := g.currentState.Drop(slots...)
+	if len(dropped) > 0 {
+		g.currentState.LogAs(state.Item, "You drop:")
+	}
+	for i, item := range dropped {
+		g.currentState.LogAs(state.Item, fmt.Sprintf("%s) %s", string(slots[i]), item.String(g.currentState.Known)))
+	}
+	if err != nil {
+		g.currentState.Log(err.Error())
+	}
+}
+
+// dropGoldHandler prompts for an amount of gold to drop
+func (g *Game) dropGoldHandler() func(termbox.Event) {
+	const prompt = "How much gold do you drop? [* for all] "
+	var amt string
+	g.currentState.Log(prompt)
 	g.render(display(g.currentState))
 
-	// Capute the input character for the item action
 	return func(e termbox.Event) {
-		g.inputHandler = g.defaultHandler
+		a := g.keys.prompt(e)
+		if a == All { // Short circuit for dropping all gold
+			amt = fmt.Sprintf("%v", g.currentState.C.Stats.Gold)
+			a = Confirm
+		}
 
-		switch e.Key {
-		case termbox.KeyEsc: // abort
+		switch {
+		case a == Cancel: // abort
+			g.inputHandler = g.defaultHandler
 			g.currentState.Log("aborted")
-		default:
-			if e.Ch == '*' {
-				g.inputHandler = g.inventoryWrapper(g.itemActionWrapper(a))
-				return
+		case a == Confirm:
+			g.inputHandler = g.defaultHandler
+			n, err := strconv.Atoi(amt)
+			if err != nil {
+				g.currentState.Log("aborted")
+				break
 			}
-			switch e.Ch {
-			case '-': // drop nothing
-			default: // try and act on something
-				var err error
-				switch a {
-				case wieldAction:
-					err = g.currentState.C.Wield(e.Ch)
-				case wearAction:
-					err = g.currentState.C.Wear(e.Ch)
-				case dropAction:
-					var item items.Item
-					item, err = g.currentState.Drop(e.Ch)
-					if err == nil {
-						g.currentState.Log("You drop:")
-						g.currentState.Log(fmt.Sprintf("%s) %s", string(e.Ch), item.String()))
-					}
-				case readAction:
-					err = g.currentState.Read(e.Ch)
-				case quaffAction:
-					var callback func() bool
-					callback, err = g.currentState.Quaff(e.Ch)
-					g.render(display(g.currentState))
-					if callback != nil {
-						for callback() { // render until callback returns false
-							g.render(display(g.currentState))
-						}
-					}
-				}
-				if err != nil {
-					g.currentState.Log(err.Error())
-				}
+			if err := g.currentState.DropGold(n); err != nil {
+				g.currentState.Log(err.Error())
 			}
+		case e.Ch >= '0' && e.Ch <= '9':
+			amt += string(e.Ch)
+			g.currentState.LogReplace(prompt + amt)
 		}
 		g.render(display(g.currentState))
 	}
 }
 
-// itemActionWrapper wraps the itemAction functon for inventory callbacks
-func (g *Game) itemActionWrapper(a action) func() func(termbox.Event) {
-	return func() func(termbox.Event) {
-		return g.itemAction(a)
-	}
+// wieldable returns true if the item can be wielded
+func wieldable(i items.Item) bool {
+	_, ok := i.(items.Weapon)
+	return ok
+}
+
+// wearable returns true if the item can be worn
+func wearable(i items.Item) bool {
+	_, ok := i.(items.Armor)
+	return ok
+}
+
+// readable returns true if the item can be read
+func readable(i items.Item) bool {
+	_, ok := i.(items.Readable)
+	return ok
+}
+
+// quaffable returns true if the item can be quaffed
+func quaffable(i items.Item) bool {
+	_, ok := i.(items.Quaffable)
+	return ok
+}
+
+// edible returns true if the item can be eaten
+func edible(i items.Item) bool {
+	_, ok := i.(items.Food)
+	return ok
 }
 
 func (g *Game) cast() func(termbox.Event) {
@@ -379,65 +567,124 @@ func (g *Game) cast() func(termbox.Event) {
 		return g.defaultHandler
 	}
 
-	g.currentState.Log("Enter your spell: ")
-	g.render(display(g.currentState))
+	const prompt = "Enter your spell: "
+	g.currentState.Log(prompt)
 
+	spells := items.KnownSpells(g.currentState.C.Stats.KnownSpells)
+	cursor := 0
 	var spell []byte
 
-	// Next 3 inputs count towards casting a spell
+	// spellList displays the page of known spells containing the cursor
+	spellList := func() {
+		lines := []string{"", "  code  name                  level  fail"}
+		if len(spells) == 0 {
+			lines = append(lines, "  You don't know any spells")
+		}
+		start := cursor / invMaxDisplay * invMaxDisplay
+		for i := start; i < start+invMaxDisplay && i < len(spells); i++ {
+			mark := "  "
+			if i == cursor {
+				mark = "> "
+			}
+			sp := spells[i]
+			lines = append(lines, fmt.Sprintf("%s%s   %-20s  %5d  %3d%%", mark, sp.Code, sp.Name, sp.Level(), g.currentState.C.FailChance(sp)))
+		}
+		lines = append(lines, "   --- type a spell code, or scroll to one and confirm, escape to cancel ---")
+		g.render(overlay(display(g.currentState), convert(lines)))
+	}
+
+	castSpell := func(code string) {
+		log.WithField("spell", code).Info("cast")
+		g.inputHandler = g.defaultHandler
+		callback, err := g.currentState.Cast(code)
+		if err != nil {
+			g.currentState.Log(err.Error())
+			g.render(display(g.currentState))
+			return
+		}
+
+		// If there was a callback func passed, that means the player is casting a projectile.
+		// Obtian the direction the player would like to cast it, before using the callback to render
+		// the animation
+		if callback != nil {
+			g.inputHandler = g.directionalSpellHandler(callback)
+		}
+		g.render(display(g.currentState))
+	}
+
+	spellList()
+
 	return func(e termbox.Event) {
-		switch e.Key {
-		case termbox.KeyEsc: // abort
-			g.currentState.Log("aborted")
-			g.inputHandler = g.defaultHandler
-		default:
+		// Spell codes use every letter, so letters always type the code even when the keymap binds them.
+		// The list scrolls with the non-letter keys bound to scrolling or moving up and down
+		a := g.keys.action(e)
+		switch {
+		case e.Ch >= 'a' && e.Ch <= 'z':
 			spell = append(spell, byte(e.Ch))
+			g.currentState.LogReplace(prompt + string(spell))
 			if len(spell) == 3 { // Spell complete
-				log.WithField("spell", string(spell)).Info("cast")
-				callback, err := g.currentState.Cast(string(spell))
-				if err != nil {
-					g.currentState.Log(err.Error())
-					g.inputHandler = g.defaultHandler
-					g.render(display(g.currentState))
-					return
-				}
-
-				// If there was a callback func passed, that means the player is casting a projectile.
-				// Obtian the direction the player would like to cast it, before using the callback to render
-				// the animation
-				if callback != nil {
-					g.inputHandler = g.directionalSpellHandler(callback)
+				if _, ok := items.LookupSpell(string(spell)); !ok { // let the player try again without spending a spell
+					g.currentState.Log(character.NotASpell.Error())
+					g.currentState.Log(prompt)
+					spell = nil
 				} else {
-					g.inputHandler = g.defaultHandler
-					g.render(display(g.currentState))
+					castSpell(string(spell))
+					return
 				}
 			}
+		case a == Cancel: // abort
+			g.currentState.Log("aborted")
+			g.inputHandler = g.defaultHandler
+			g.render(display(g.currentState))
+			return
+		case a == MoveUp, a == ScrollUp:
+			if cursor > 0 {
+				cursor--
+			}
+		case a == MoveDown, a == ScrollDown:
+			if cursor < len(spells)-1 {
+				cursor++
+			}
+		case a == Confirm: // cast the selected spell
+			if len(spells) > 0 {
+				castSpell(spells[cursor].Code)
+				return
+			}
+		case e.Key == termbox.KeyBackspace, e.Key == termbox.KeyBackspace2:
+			if len(spell) > 0 {
+				spell = spell[:len(spell)-1]
+				g.currentState.LogReplace(prompt + string(spell))
+			}
+		default:
+			return
 		}
-		g.render(display(g.currentState))
+		spellList()
 	}
 }
 
 func (g *Game) help() func(termbox.Event) {
 
+	pages := append(append([]string{}, help...), keysHelp(g.keys)...)
+
 	// Display the first help screen
 	i := 0
-	g.renderSplash(help[i])
+	g.renderSplash(pages[i])
 	i++
 
 	return func(e termbox.Event) {
-		switch e.Key {
-		case termbox.KeyEnter: // exit
+		switch g.keys.action(e) {
+		case Confirm: // exit
 			fallthrough
-		case termbox.KeyEsc: // abort
+		case Cancel: // abort
 			g.inputHandler = g.defaultHandler
 			g.render(display(g.currentState))
-		case termbox.KeySpace:
-			if i >= len(help) { // run out of help menus
+		case NextPage:
+			if i >= len(pages) { // run out of help menus
 				g.inputHandler = g.defaultHandler
 				g.render(display(g.currentState))
 				return
 			}
-			g.renderSplash(help[i])
+			g.renderSplash(pages[i])
 			i++
 		}
 	}
@@ -473,31 +720,55 @@ func (g *Game) enterAction() func(termbox.Event) {
 	}
 }
 
+// cursor returns a targeting cursor that starts on the player and is moved with the movement keys. moved is called each time
+// the cursor moves, and done with the chosen coordinate when enter or the rest key is pressed. Escape cancels
+func (g *Game) cursor(moved, done func(types.Coordinate)) func(termbox.Event) {
+	c := types.Coordinate(g.currentState.C.Location())
+
+	show := func() {
+		moved(c)
+		g.render(display(g.currentState))
+		g.renderCursor(c)
+	}
+	show()
+
+	return func(e termbox.Event) {
+		switch g.keys.action(e) {
+		case Cancel:
+			g.inputHandler = g.defaultHandler
+			g.render(display(g.currentState))
+		case Confirm, Rest:
+			g.inputHandler = g.defaultHandler
+			done(c)
+			g.render(display(g.currentState))
+		default:
+			d, ok := g.keys.direction(e)
+			if !ok {
+				return
+			}
+			if next := types.Move(c, d); next.X >= 0 && next.Y >= 0 && next.Y < len(g.currentState.CurrentMap()) && next.X < len(g.currentState.CurrentMap()[next.Y]) {
+				c = next
+			}
+			show()
+		}
+	}
+}
+
+// lookAction describes whatever is under the cursor as it moves around the map
+func (g *Game) lookAction() func(termbox.Event) {
+	g.currentState.Log("Look where? (escape to stop)")
+	describe := func(c types.Coordinate) { g.currentState.LogReplace(g.currentState.Describe(c)) }
+	return g.cursor(describe, describe)
+}
+
 func (g *Game) directionalSpellHandler(cb func(types.Direction) bool) func(termbox.Event) {
 
 	g.currentState.Log("What Direction? ")
 	g.render(display(g.currentState))
 
 	return func(e termbox.Event) {
-		var d types.Direction
-		switch e.Ch {
-		case 'b':
-			d = types.DownLeft
-		case 'n':
-			d = types.DownRight
-		case 'y':
-			d = types.UpLeft
-		case 'u':
-			d = types.UpRight
-		case 'h':
-			d = types.Left
-		case 'k':
-			d = types.Up
-		case 'l':
-			d = types.Right
-		case 'j':
-			d = types.Down
-		default: // keep waiting for a valid direction to be entered
+		d, ok := g.keys.direction(e)
+		if !ok { // keep waiting for a valid direction to be entered
 			return
 		}
 
@@ -0,0 +1,114 @@
+package game
+
+import (
+	"fmt"
+
+	termbox "github.com/nsf/termbox-go"
+	"github.com/thorfour/larn/pkg/game/state"
+	"github.com/thorfour/larn/pkg/io"
+)
+
+const (
+	historyPage = 15 // number of messages to display on a page of the message history
+)
+
+// historyFilters are the keys that filter the message history by category
+var historyFilters = map[rune][]state.Category{
+	'a': nil,
+	's': {state.System},
+	'c': {state.Combat},
+	'i': {state.Item},
+}
+
+// withLog replaces the status log at the bottom of the display with the lines
+func withLog(d [][]io.Runeable, lines []string) [][]io.Runeable {
+	return append(d[:len(d)-logLength], convert(lines)...)
+}
+
+// more pages through the messages an action logged that didn't fit in the status log, then returns to next
+func (g *Game) more(msgs []state.Message, next func(termbox.Event)) func(termbox.Event) {
+	offset := 0
+
+	page := func() {
+		var lines []string
+		for ; len(lines) < logLength-1 && offset < len(msgs); offset++ {
+			lines = append(lines, msgs[offset].Text)
+		}
+		for len(lines) < logLength-1 {
+			lines = append(lines, "")
+		}
+		g.render(withLog(display(g.currentState), append(lines, "--More--")))
+	}
+	page()
+
+	return func(e termbox.Event) {
+		switch g.keys.action(e) {
+		case NextPage, Confirm:
+			if len(msgs)-offset > logLength { // still more than the status log holds
+				page()
+				return
+			}
+		case Cancel: // skip the remaining messages
+		default:
+			return
+		}
+
+		g.inputHandler = next
+		g.render(display(g.currentState))
+	}
+}
+
+// historyAction displays the message history a page at a time, starting with the most recent messages
+func (g *Game) historyAction() func(termbox.Event) {
+	var msgs []state.Message
+	offset := 0 // number of messages hidden below the current page
+
+	filter := func(categories []state.Category) {
+		msgs = g.currentState.Messages(categories...)
+		offset = 0
+	}
+	filter(nil)
+
+	page := func() {
+		end := len(msgs) - offset
+		start := end - historyPage
+		if start < 0 {
+			start = 0
+		}
+
+		lines := []string{"Message history:"}
+		for _, m := range msgs[start:end] {
+			lines = append(lines, fmt.Sprintf("%6d %-6s %s", m.Turn, m.Category, m.Text))
+		}
+		lines = append(lines, "   --- up/down to scroll, a/s/c/i to filter all/system/combat/item, escape to exit ---")
+		g.render(overlay(display(g.currentState), convert(lines)))
+	}
+	page()
+
+	return func(e termbox.Event) {
+		if categories, ok := historyFilters[e.Ch]; ok {
+			filter(categories)
+			page()
+			return
+		}
+
+		switch g.keys.action(e) {
+		case Cancel, Confirm:
+			g.inputHandler = g.defaultHandler
+			g.render(display(g.currentState))
+			return
+		case MoveUp, ScrollUp: // older messages
+			if offset+historyPage < len(msgs) {
+				offset += historyPage
+			}
+		case MoveDown, ScrollDown: // newer messages
+			offset -= historyPage
+			if offset < 0 {
+				offset = 0
+			}
+		default:
+			return
+		}
+		page()
+	}
+}
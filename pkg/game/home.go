@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	termbox "github.com/nsf/termbox-go"
+	"github.com/thorfour/larn/pkg/game/state/items"
 )
 
 func homePage(name string, time int) string {
@@ -24,16 +25,46 @@ func homePage(name string, time int) string {
 	return s
 }
 
+// winPage is displayed when the player brings the potion home in time
+func winPage(name string, taxes int) string {
+	s := "\n\n\n\n  Congratulations " + name + ".  You found a potion of cure dianthroritis.\n"
+	s += "\n  Frankly, No one thought you could do it.  Boy!  Did you surprise them!\n"
+	s += "  The doctor is now administering the potion, and in a few moments\n"
+	s += "  your daughter should be well on her way to recovery.\n"
+	s += "\n  The potion is working!  The doctor thinks that\n"
+	s += "  your daughter will recover in a few days.  Congratulations!\n\n"
+	s += fmt.Sprintf("  The Larn Revenue Service has assessed you %v gp in taxes on your winnings.\n\n\n", taxes)
+	s += "  ----- Press escape to leave -----"
+
+	return s
+}
+
 func (g *Game) homeHandler() func(termbox.Event) {
+	if g.currentState.TimeLeft() > 0 && g.currentState.C.CarryingPotion(items.CureDianthroritis) {
+		return g.winHandler()
+	}
+
 	g.renderSplash(homePage(g.currentState.Name, g.currentState.TimeLeft()))
 	if g.currentState.TimeLeft() <= 0 {
 		// TODO handle game over
 	}
 	return func(e termbox.Event) {
-		switch e.Key {
-		case termbox.KeyEsc: // Exit
+		switch g.keys.action(e) {
+		case Cancel: // Exit
 			g.inputHandler = g.defaultHandler
 			g.render(display(g.currentState))
 		}
 	}
 }
+
+// winHandler ends the game with a win, taxes are assessed on the players winnings
+func (g *Game) winHandler() func(termbox.Event) {
+	g.renderSplash(winPage(g.currentState.Name, g.currentState.AssessTaxes(int(g.currentState.C.Stats.Gold)+account)))
+	g.saveTaxes()
+	return func(e termbox.Event) {
+		switch g.keys.action(e) {
+		case Cancel: // Exit
+			g.err = Won
+		}
+	}
+}
@@ -0,0 +1,404 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	termbox "github.com/nsf/termbox-go"
+	"github.com/thorfour/larn/pkg/game/state/types"
+)
+
+// Action is a command that can be bound to a key
+type Action string
+
+const (
+	None Action = ""
+
+	MoveLeft      Action = "move_left"
+	MoveDown      Action = "move_down"
+	MoveUp        Action = "move_up"
+	MoveRight     Action = "move_right"
+	MoveUpLeft    Action = "move_northwest"
+	MoveUpRight   Action = "move_northeast"
+	MoveDownLeft  Action = "move_southwest"
+	MoveDownRight Action = "move_southeast"
+
+	RunLeft      Action = "run_left"
+	RunDown      Action = "run_down"
+	RunUp        Action = "run_up"
+	RunRight     Action = "run_right"
+	RunUpLeft    Action = "run_northwest"
+	RunUpRight   Action = "run_northeast"
+	RunDownLeft  Action = "run_southwest"
+	RunDownRight Action = "run_southeast"
+
+	PickUp          Action = "pick_up"
+	Search          Action = "search"
+	Drop            Action = "drop"
+	Version         Action = "version"
+	Help            Action = "help"
+	Weight          Action = "pack_weight"
+	Inventory       Action = "inventory"
+	Diagnostic      Action = "diagnostic"
+	Travel          Action = "travel"
+	Look            Action = "look"
+	Explore         Action = "explore"
+	Rest            Action = "rest"
+	RestUntilHealed Action = "rest_until_healed"
+	Teleport        Action = "teleport"
+	Cast            Action = "cast"
+	Read            Action = "read"
+	Quaff           Action = "quaff"
+	Wear            Action = "wear"
+	TakeOff         Action = "take_off"
+	Wield           Action = "wield"
+	Taxes           Action = "tax_status"
+	Discoveries     Action = "discoveries"
+	History         Action = "message_history"
+	Bestiary        Action = "bestiary"
+	Eat             Action = "eat"
+	SaveGame        Action = "save"
+	QuitGame        Action = "quit"
+	Enter           Action = "enter"
+	Pray            Action = "pray"
+
+	// Prompt actions
+	Confirm    Action = "confirm"
+	Cancel     Action = "cancel"
+	NextPage   Action = "next_page"
+	ScrollUp   Action = "scroll_up"
+	ScrollDown Action = "scroll_down"
+
+	// Building actions, bound apart from the others since buildings reuse their letters
+	PayTaxes Action = "pay_taxes"
+	Deposit  Action = "deposit"
+	Withdraw Action = "withdraw"
+	SellGem  Action = "sell_gem"
+	All      Action = "all" // pay, deposit, withdraw, sell or drop everything at once
+)
+
+// actions is every action that can be bound, in the order they're listed in the help
+var actions = []Action{
+	MoveLeft, MoveDown, MoveUp, MoveRight, MoveUpLeft, MoveUpRight, MoveDownLeft, MoveDownRight,
+	RunLeft, RunDown, RunUp, RunRight, RunUpLeft, RunUpRight, RunDownLeft, RunDownRight,
+	PickUp, Search, Drop, Version, Help, Weight, Inventory, Diagnostic, Travel, Look, Explore, Rest, RestUntilHealed,
+	Teleport, Cast, Read, Quaff, Wear, TakeOff, Wield, Taxes, Discoveries, History, Bestiary, Eat, SaveGame, QuitGame,
+	Enter, Pray, Confirm, Cancel, NextPage, ScrollUp, ScrollDown,
+}
+
+// promptActions is every action that can be bound while answering a building's prompts
+var promptActions = []Action{PayTaxes, Deposit, Withdraw, SellGem, All}
+
+// moves maps the movement actions to their direction
+var moves = map[Action]types.Direction{
+	MoveLeft:      types.Left,
+	MoveDown:      types.Down,
+	MoveUp:        types.Up,
+	MoveRight:     types.Right,
+	MoveUpLeft:    types.UpLeft,
+	MoveUpRight:   types.UpRight,
+	MoveDownLeft:  types.DownLeft,
+	MoveDownRight: types.DownRight,
+}
+
+// runs maps the running actions to their direction
+var runs = map[Action]types.Direction{
+	RunLeft:      types.Left,
+	RunDown:      types.Down,
+	RunUp:        types.Up,
+	RunRight:     types.Right,
+	RunUpLeft:    types.UpLeft,
+	RunUpRight:   types.UpRight,
+	RunDownLeft:  types.DownLeft,
+	RunDownRight: types.DownRight,
+}
+
+// Key is a key press, either a character or a special key such as an arrow
+type Key struct {
+	Ch  rune
+	Key termbox.Key
+}
+
+// keyNames are the names of the special keys used in keymap files
+var keyNames = map[string]termbox.Key{
+	"Esc":       termbox.KeyEsc,
+	"Enter":     termbox.KeyEnter,
+	"Space":     termbox.KeySpace,
+	"Tab":       termbox.KeyTab,
+	"Backspace": termbox.KeyBackspace2,
+	"Up":        termbox.KeyArrowUp,
+	"Down":      termbox.KeyArrowDown,
+	"Left":      termbox.KeyArrowLeft,
+	"Right":     termbox.KeyArrowRight,
+	"Home":      termbox.KeyHome,
+	"End":       termbox.KeyEnd,
+	"PgUp":      termbox.KeyPgup,
+	"PgDn":      termbox.KeyPgdn,
+	"Insert":    termbox.KeyInsert,
+	"Delete":    termbox.KeyDelete,
+}
+
+// keyOf returns the key pressed in the event
+func keyOf(e termbox.Event) Key {
+	if e.Ch != 0 {
+		return Key{Ch: e.Ch}
+	}
+	return Key{Key: e.Key}
+}
+
+// parseKey returns the key for a single character or the name of a special key
+func parseKey(s string) (Key, error) {
+	if k, ok := keyNames[s]; ok {
+		return Key{Key: k}, nil
+	}
+	if r := []rune(s); len(r) == 1 {
+		return Key{Ch: r[0]}, nil
+	}
+	return Key{}, fmt.Errorf("unknown key %q", s)
+}
+
+// String returns the name of the key as used in keymap files
+func (k Key) String() string {
+	if k.Ch != 0 {
+		return string(k.Ch)
+	}
+	for name, key := range keyNames {
+		if key == k.Key {
+			return name
+		}
+	}
+	return fmt.Sprintf("key %v", k.Key)
+}
+
+// Keymap maps keys to the actions they perform
+type Keymap map[Key]Action
+
+// action returns the action bound to the key pressed in the event, None if it isn't bound
+func (k Keymap) action(e termbox.Event) Action {
+	return k[keyOf(e)]
+}
+
+// direction returns the direction of the movement action bound to the key pressed in the event
+func (k Keymap) direction(e termbox.Event) (types.Direction, bool) {
+	d, ok := moves[k.action(e)]
+	return d, ok
+}
+
+// bind binds the keys to the action
+func (k Keymap) bind(a Action, keys ...Key) Keymap {
+	for _, key := range keys {
+		k[key] = a
+	}
+	return k
+}
+
+// copy returns a copy of the keymap
+func (k Keymap) copy() Keymap {
+	c := make(Keymap, len(k))
+	for key, a := range k {
+		c[key] = a
+	}
+	return c
+}
+
+// help returns a line for each of the actions that's bound listing its keys
+func (k Keymap) help(actions []Action) []string {
+	bound := make(map[Action][]string)
+	for key, a := range k {
+		bound[a] = append(bound[a], key.String())
+	}
+
+	var lines []string
+	for _, a := range actions {
+		if len(bound[a]) == 0 {
+			continue
+		}
+		sort.Strings(bound[a])
+		lines = append(lines, fmt.Sprintf("%-18s %s", a, strings.Join(bound[a], " ")))
+	}
+	return lines
+}
+
+// keysHelpRows is the number of bindings listed in each column of a key bindings help page
+const keysHelpRows = 18
+
+// keysHelp returns the help pages listing the key bindings, two columns to a page
+func keysHelp(k Keys) []string {
+	lines := append(k.help(actions), k.Prompt.help(promptActions)...)
+
+	var pages []string
+	for start := 0; start < len(lines); start += 2 * keysHelpRows {
+		page := "\n\t              Key Bindings\n\n"
+		for i := start; i < start+keysHelpRows && i < len(lines); i++ {
+			page += fmt.Sprintf("\t%-36s", lines[i])
+			if j := i + keysHelpRows; j < len(lines) {
+				page += lines[j]
+			}
+			page += "\n"
+		}
+		pages = append(pages, page+"\n         --- Press enter to exit, space for more help ---  \n")
+	}
+	return pages
+}
+
+// ch returns the key for a character
+func ch(r rune) Key { return Key{Ch: r} }
+
+// special returns the key for a special key
+func special(k termbox.Key) Key { return Key{Key: k} }
+
+// viKeys is the default keymap
+var viKeys = Keymap{
+	ch('h'): MoveLeft, ch('j'): MoveDown, ch('k'): MoveUp, ch('l'): MoveRight,
+	ch('y'): MoveUpLeft, ch('u'): MoveUpRight, ch('b'): MoveDownLeft, ch('n'): MoveDownRight,
+	ch('H'): RunLeft, ch('J'): RunDown, ch('K'): RunUp, ch('L'): RunRight,
+	ch('Y'): RunUpLeft, ch('U'): RunUpRight, ch('B'): RunDownLeft, ch('N'): RunDownRight,
+	ch(','): PickUp, ch('^'): Search, ch('d'): Drop, ch('v'): Version, ch('?'): Help, ch('g'): Weight,
+	ch('i'): Inventory, ch('A'): Diagnostic, ch('_'): Travel, ch(';'): Look, ch('x'): Explore,
+	ch('.'): Rest, ch('R'): RestUntilHealed, ch('Z'): Teleport, ch('c'): Cast, ch('r'): Read,
+	ch('q'): Quaff, ch('W'): Wear, ch('T'): TakeOff, ch('w'): Wield, ch('P'): Taxes, ch('D'): Discoveries,
+	ch('m'): History, ch('M'): Bestiary, ch('e'): Eat, ch('S'): SaveGame, ch('Q'): QuitGame,
+	ch('E'): Enter, ch('p'): Pray,
+	special(termbox.KeyEnter): Confirm, special(termbox.KeyEsc): Cancel, special(termbox.KeySpace): NextPage,
+	special(termbox.KeyArrowUp): ScrollUp, special(termbox.KeyArrowDown): ScrollDown,
+}
+
+// arrowKeys moves with the arrow keys as well as the vi keys
+var arrowKeys = viKeys.copy().
+	bind(MoveLeft, special(termbox.KeyArrowLeft)).
+	bind(MoveDown, special(termbox.KeyArrowDown)).
+	bind(MoveUp, special(termbox.KeyArrowUp)).
+	bind(MoveRight, special(termbox.KeyArrowRight))
+
+// numpadKeys moves with the numeric keypad, with num lock on or off, as well as the vi keys. Counts can't be typed before commands
+var numpadKeys = arrowKeys.copy().
+	bind(MoveDownLeft, ch('1'), special(termbox.KeyEnd)).
+	bind(MoveDown, ch('2')).
+	bind(MoveDownRight, ch('3'), special(termbox.KeyPgdn)).
+	bind(MoveLeft, ch('4')).
+	bind(Rest, ch('5')).
+	bind(MoveRight, ch('6')).
+	bind(MoveUpLeft, ch('7'), special(termbox.KeyHome)).
+	bind(MoveUp, ch('8')).
+	bind(MoveUpRight, ch('9'), special(termbox.KeyPgup))
+
+// wasdKeys moves with the keys around w, commands those keys displace move to the freed vi keys
+var wasdKeys = Keymap{
+	ch('a'): MoveLeft, ch('s'): MoveDown, ch('w'): MoveUp, ch('d'): MoveRight,
+	ch('q'): MoveUpLeft, ch('e'): MoveUpRight, ch('z'): MoveDownLeft, ch('c'): MoveDownRight,
+	ch('A'): RunLeft, ch('S'): RunDown, ch('W'): RunUp, ch('D'): RunRight,
+	ch('Q'): RunUpLeft, ch('E'): RunUpRight, ch('Z'): RunDownLeft, ch('C'): RunDownRight,
+	ch(','): PickUp, ch('^'): Search, ch('j'): Drop, ch('v'): Version, ch('?'): Help, ch('g'): Weight,
+	ch('i'): Inventory, ch('_'): Travel, ch(';'): Look, ch('x'): Explore,
+	ch('.'): Rest, ch('R'): RestUntilHealed, ch('k'): Cast, ch('r'): Read,
+	ch('u'): Quaff, ch('h'): Wear, ch('T'): TakeOff, ch('l'): Wield, ch('P'): Taxes, ch('y'): Discoveries,
+	ch('m'): History, ch('M'): Bestiary, ch('n'): Eat, ch('V'): SaveGame, ch('K'): QuitGame,
+	ch('N'): Enter, ch('p'): Pray,
+	special(termbox.KeyEnter): Confirm, special(termbox.KeyEsc): Cancel, special(termbox.KeySpace): NextPage,
+}.bind(MoveLeft, special(termbox.KeyArrowLeft)).
+	bind(MoveDown, special(termbox.KeyArrowDown)).
+	bind(MoveUp, special(termbox.KeyArrowUp)).
+	bind(MoveRight, special(termbox.KeyArrowRight))
+
+// promptKeys are the keys every preset uses in building prompts
+var promptKeys = Keymap{
+	ch('p'): PayTaxes, ch('d'): Deposit, ch('w'): Withdraw, ch('s'): SellGem, ch('*'): All,
+}
+
+// Keymaps are the built in keymaps by name
+var Keymaps = map[string]Keymap{
+	"vi":     viKeys,
+	"arrows": arrowKeys,
+	"numpad": numpadKeys,
+	"wasd":   wasdKeys,
+}
+
+// Keys are the key bindings used to play. Building prompts check the prompt bindings first, so they can reuse keys
+// bound to other actions
+type Keys struct {
+	Keymap
+	Prompt Keymap
+}
+
+// prompt returns the action bound to the key pressed in the event while answering a building's prompt
+func (k Keys) prompt(e termbox.Event) Action {
+	if a := k.Prompt.action(e); a != None {
+		return a
+	}
+	return k.action(e)
+}
+
+// keymapFile is the format of a keymap file. Bindings are applied on top of the preset, binding a key to "" unbinds it
+type keymapFile struct {
+	Preset   string            `json:"preset"`
+	Bindings map[string]Action `json:"bindings"`
+	Prompt   map[string]Action `json:"prompt_bindings"` // bindings used in building prompts
+}
+
+// LoadKeymap returns the built in keymap with the given name, or reads the keymap from a json file at that path
+func LoadKeymap(name string) (Keys, error) {
+	if k, ok := Keymaps[name]; ok {
+		return Keys{Keymap: k, Prompt: promptKeys}, nil
+	}
+
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return Keys{}, err
+	}
+
+	f := new(keymapFile)
+	if err := json.Unmarshal(b, f); err != nil {
+		return Keys{}, err
+	}
+
+	base := viKeys
+	if f.Preset != "" {
+		var ok bool
+		if base, ok = Keymaps[f.Preset]; !ok {
+			return Keys{}, fmt.Errorf("unknown keymap preset %q", f.Preset)
+		}
+	}
+
+	k, err := base.apply(f.Bindings, actions)
+	if err != nil {
+		return Keys{}, err
+	}
+	p, err := promptKeys.apply(f.Prompt, promptActions)
+	if err != nil {
+		return Keys{}, err
+	}
+	return Keys{Keymap: k, Prompt: p}, nil
+}
+
+// apply returns a copy of the keymap with the bindings applied. The bindings may only use the given actions
+func (k Keymap) apply(bindings map[string]Action, valid []Action) (Keymap, error) {
+	c := k.copy()
+	for s, a := range bindings {
+		key, err := parseKey(s)
+		if err != nil {
+			return nil, err
+		}
+		if a == None {
+			delete(c, key)
+			continue
+		}
+		if !validAction(a, valid) {
+			return nil, fmt.Errorf("unknown action %q", a)
+		}
+		c[key] = a
+	}
+	return c, nil
+}
+
+// validAction returns true if the action is one of the valid actions
+func validAction(a Action, valid []Action) bool {
+	for _, v := range valid {
+		if v == a {
+			return true
+		}
+	}
+	return false
+}
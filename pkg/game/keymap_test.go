@@ -0,0 +1,103 @@
+package game
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	termbox "github.com/nsf/termbox-go"
+	"github.com/thorfour/larn/pkg/game/state/types"
+)
+
+// TestPresets ensures every preset can move in every direction and answer prompts
+func TestPresets(t *testing.T) {
+	for name, k := range Keymaps {
+		bound := make(map[Action]bool)
+		for _, a := range k {
+			bound[a] = true
+		}
+		for a := range moves {
+			if !bound[a] {
+				t.Errorf("%s doesn't bind %s", name, a)
+			}
+		}
+		for _, a := range []Action{Confirm, Cancel, NextPage, Help, QuitGame} {
+			if !bound[a] {
+				t.Errorf("%s doesn't bind %s", name, a)
+			}
+		}
+
+		// Letters type spell codes, so lists must scroll with other keys
+		scrolls := make(map[Action]bool)
+		for key, a := range k {
+			if key.Ch < 'a' || key.Ch > 'z' {
+				scrolls[a] = true
+			}
+		}
+		if !(scrolls[ScrollUp] || scrolls[MoveUp]) || !(scrolls[ScrollDown] || scrolls[MoveDown]) {
+			t.Errorf("%s can't scroll lists without letters", name)
+		}
+	}
+
+	if _, ok := Keymaps["vi"].direction(termbox.Event{Key: termbox.KeyArrowLeft}); ok {
+		t.Error("vi keymap moved with the arrow keys")
+	}
+	if d, ok := Keymaps["arrows"].direction(termbox.Event{Key: termbox.KeyArrowLeft}); !ok || d != types.Left {
+		t.Error("arrows keymap didn't move with the arrow keys")
+	}
+	if d, ok := Keymaps["numpad"].direction(termbox.Event{Ch: '7'}); !ok || d != types.UpLeft {
+		t.Error("numpad keymap didn't move with the keypad")
+	}
+}
+
+// TestLoadKeymap ensures keymap files are applied on top of their preset
+func TestLoadKeymap(t *testing.T) {
+	dir, err := ioutil.TempDir("", "larn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "keys.json")
+	if err := ioutil.WriteFile(path, []byte(`{"preset": "arrows", "bindings": {"Delete": "cancel", "h": ""}, "prompt_bindings": {"t": "pay_taxes"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	k, err := LoadKeymap(path)
+	if err != nil {
+		t.Fatal("unable to load keymap file", err)
+	}
+	if k.action(termbox.Event{Key: termbox.KeyDelete}) != Cancel {
+		t.Error("binding wasn't applied")
+	}
+	if k.action(termbox.Event{Ch: 'h'}) != None {
+		t.Error("key wasn't unbound")
+	}
+	if _, ok := k.direction(termbox.Event{Key: termbox.KeyArrowLeft}); !ok {
+		t.Error("preset wasn't applied")
+	}
+	if k.prompt(termbox.Event{Ch: 't'}) != PayTaxes || k.prompt(termbox.Event{Ch: 'p'}) != PayTaxes {
+		t.Error("prompt binding wasn't applied")
+	}
+	if k.prompt(termbox.Event{Key: termbox.KeyDelete}) != Cancel {
+		t.Error("prompt didn't fall back to the other bindings")
+	}
+	if Keymaps["arrows"].action(termbox.Event{Ch: 'h'}) != MoveLeft {
+		t.Error("loading a keymap changed the preset")
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"bindings": {"h": "dance"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadKeymap(path); err == nil {
+		t.Error("keymap with an unknown action loaded")
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"prompt_bindings": {"q": "quit"}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadKeymap(path); err == nil {
+		t.Error("prompt binding to a map action loaded")
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	termbox "github.com/nsf/termbox-go"
 	log "github.com/sirupsen/logrus"
+	"github.com/thorfour/larn/pkg/game/data"
 )
 
 func tax(taxes int) string {
@@ -34,18 +35,20 @@ func lrsPage(taxes int, g uint) string {
 }
 
 func (g *Game) lrsHandler() func(termbox.Event) {
+	if p := g.currentState.LatePenalty(); p > 0 { // Charge a penalty for taxes left unpaid from the last game
+		g.saveTaxes()
+		g.renderSplash(lrsPage(g.currentState.Taxes, g.currentState.C.Stats.Gold) + fmt.Sprintf("\n  A late penalty of %v gp has been added to your unpaid taxes.", p))
+		time.Sleep(time.Millisecond * 1500)
+	}
 	g.renderSplash(lrsPage(g.currentState.Taxes, g.currentState.C.Stats.Gold))
 	return func(e termbox.Event) {
-		switch e.Key {
-		case termbox.KeyEsc: // Exit
+		switch g.keys.prompt(e) {
+		case Cancel: // Exit
 			g.inputHandler = g.defaultHandler
 			g.render(display(g.currentState))
-		default:
-			switch e.Ch {
-			case 'p':
-				g.renderSplash(lrsPage(g.currentState.Taxes, g.currentState.C.Stats.Gold) + "\n  How much? ")
-				g.inputHandler = g.payTaxesHandler()
-			}
+		case PayTaxes:
+			g.renderSplash(lrsPage(g.currentState.Taxes, g.currentState.C.Stats.Gold) + "\n  How much? ")
+			g.inputHandler = g.payTaxesHandler()
 		}
 	}
 }
@@ -53,21 +56,20 @@ func (g *Game) lrsHandler() func(termbox.Event) {
 func (g *Game) payTaxesHandler() func(termbox.Event) {
 	var amt string
 	return func(e termbox.Event) {
-		switch e.Key {
-		case termbox.KeyEsc: // Exit
+		switch g.keys.action(e) {
+		case Cancel: // Exit
 			g.inputHandler = g.defaultHandler
 			g.render(display(g.currentState))
-		case termbox.KeyEnter: // Execute payment
+		case Confirm: // Execute payment
 			amount, err := strconv.Atoi(amt)
 			if err != nil {
 				log.WithField("amount", amt).Error("unable to convert tax input to number")
 			}
-			if g.currentState.C.Stats.Gold < uint(amount) {
-				g.renderSplash(lrsPage(g.currentState.Taxes, g.currentState.C.Stats.Gold) + "\n  How much? " + amt + "\n  You don't have that much.")
+			if err := g.currentState.PayTaxes(amount); err != nil {
+				g.renderSplash(lrsPage(g.currentState.Taxes, g.currentState.C.Stats.Gold) + "\n  How much? " + amt + "\n  " + err.Error())
 				time.Sleep(time.Millisecond * 700) // blink the message
-			} else { // pay the taxes
-				g.currentState.C.Stats.Gold -= uint(amount)
-				g.currentState.Taxes -= amount
+			} else {
+				g.saveTaxes()
 			}
 			g.inputHandler = g.lrsHandler()
 		default:
@@ -97,3 +99,28 @@ func (g *Game) payTaxesHandler() func(termbox.Event) {
 		}
 	}
 }
+
+// loadTaxes charges the taxes owed from previous games into the current game
+func (g *Game) loadTaxes() {
+	if g.settings.TaxFile == "" {
+		return
+	}
+
+	t, err := data.LoadTaxes(g.settings.TaxFile)
+	if err != nil {
+		log.WithField("error", err).Error("unable to load tax record")
+		return
+	}
+	g.currentState.Taxes = t.Owed
+}
+
+// saveTaxes records the taxes owed so they carry into the next game
+func (g *Game) saveTaxes() {
+	if g.settings.TaxFile == "" {
+		return
+	}
+
+	if err := data.SaveTaxes(g.settings.TaxFile, &data.Taxes{Owed: g.currentState.Taxes}); err != nil {
+		log.WithField("error", err).Error("unable to save tax record")
+	}
+}
@@ -0,0 +1,119 @@
+package game
+
+import (
+	"sort"
+
+	termbox "github.com/nsf/termbox-go"
+	log "github.com/sirupsen/logrus"
+	"github.com/thorfour/larn/pkg/game/state/items"
+)
+
+// menu is a reusable selection menu. It offers the inventory items that satisfy the filter, or the given list
+type menu struct {
+	title  string                // prompt displayed above the items
+	empty  string                // logged instead of opening the menu when nothing matches the filter
+	filter func(items.Item) bool // items to offer, nil offers every item
+	list   []string              // choices to offer instead of the inventory, each starting with its letter
+	multi  bool                  // allow selecting more than one item
+	keys   map[rune]func()       // extra keys the menu accepts, called after the menu closes
+	done   func([]rune)          // called with the letters of the selected choices
+}
+
+// selectMenu returns an input handler that displays the menu and waits for a selection. Escape cancels the menu
+func (g *Game) selectMenu(m menu) func(termbox.Event) {
+	list := m.list
+	if list == nil {
+		list = g.currentState.InventoryIf(m.filter)
+	}
+	if len(list) == 0 && len(m.keys) == 0 {
+		g.currentState.Log(m.empty)
+		g.render(display(g.currentState))
+		return g.defaultHandler
+	}
+
+	// Map the listing to the slot letters
+	slots := make(map[rune]bool, len(list))
+	for _, l := range list {
+		slots[rune(l[0])] = true
+	}
+
+	offset := 0
+	selected := make(map[rune]bool)
+
+	page := func() []string {
+		lines := []string{"", m.title}
+		for i := offset; i < offset+invMaxDisplay && i < len(list); i++ {
+			mark := "  "
+			if selected[rune(list[i][0])] {
+				mark = "+ "
+			}
+			lines = append(lines, mark+list[i])
+		}
+
+		help := "   --- space for more, escape to cancel"
+		if m.multi {
+			help += ", enter to confirm"
+		}
+		return append(lines, help+" ---")
+	}
+
+	close := func() {
+		g.inputHandler = g.defaultHandler
+		g.render(display(g.currentState))
+	}
+
+	g.render(overlay(display(g.currentState), convert(page())))
+
+	return func(e termbox.Event) {
+		switch g.keys.action(e) {
+		case Cancel:
+			g.currentState.Log("aborted")
+			close()
+			return
+		case NextPage: // wraps back to the first page
+			offset += invMaxDisplay
+			if offset >= len(list) {
+				offset = 0
+			}
+			g.render(overlay(display(g.currentState), convert(page())))
+			return
+		case Confirm: // confirm a multi selection
+			if !m.multi || len(selected) == 0 {
+				return
+			}
+			var choice []rune
+			for r := range selected {
+				choice = append(choice, r)
+			}
+			sort.Slice(choice, func(i, j int) bool { return choice[i] < choice[j] })
+			close()
+			m.done(choice)
+			g.render(display(g.currentState))
+			return
+		}
+
+		if f, ok := m.keys[e.Ch]; ok {
+			close()
+			f()
+			return
+		}
+
+		if !slots[e.Ch] {
+			log.WithField("input", string(e.Ch)).Debug("recieved invalid menu input")
+			return
+		}
+
+		if m.multi { // toggle the item
+			selected[e.Ch] = !selected[e.Ch]
+			if !selected[e.Ch] {
+				delete(selected, e.Ch)
+			}
+			g.render(overlay(display(g.currentState), convert(page())))
+			return
+		}
+
+		close()
+		m.done([]rune{e.Ch})
+		g.render(display(g.currentState))
+	}
+}
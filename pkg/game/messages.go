@@ -35,16 +35,22 @@ var help = []string{`
 	u  move northeast      U  run northeast     W  wear armor
 	b  move southwest      B  run southwest     T  take off armor
 	n  move southeast      N  run southeast     w  wield a weapon
-	^  identify a trap     g  give present pack weight  P  give tax status
+	^  search for traps    g  give present pack weight  P  give tax status
 	d  drop an item        i  inventory your pockets    Q  quit the game
 	v  print program version   S  save the game         D  list all items found
 	?  this help screen        A  create diagnostic file    e  eat something
 					(wizards only)
+	R  rest until healed       <number>.  rest that many turns
+	_  travel to a known place x  explore the level        ;  look at something
+	M  list monsters seen      m  message history
 	larn ++   restore checkpointed game
 	larn -s   list the scoreboard
 	larn -i   list scores with inventories
 	larn -n   suppress welcome message when beginning a game
 	larn -h   print out all the command line options
+	larn -monsters      print the monster table (-format json for json)
+	larn -theme <name>  color theme: classic, color or a json theme file
+	larn -keys <name>   key bindings: vi, arrows, numpad, wasd or a json keymap file
 	larn -<number>      specify difficulty of the game (may be used with -n)
 	larn -o<optsfile>   specify the .larnopts file to be used
 	larn -c           create new scoreboards -- prompts for a password
@@ -67,10 +73,9 @@ When in the store, trading post, school, or home, an <escape> will get you out.
 
 larn -l           print out the larn log file
 
-When casting a spell, if you need a list of spells you can cast, type 'D' as
-the first letter of your spell.  The available list of spells will be shown,
-after which you may enter the spell code.  This only works on the 1st letter
-of the spell you are casting.
+When casting a spell, the spells you know are listed along with the level
+needed to cast them and their chance of failure.  Type the spell code, or
+choose a spell with the arrow keys and press <enter> to cast it.
 
 The Author of Larn is Noah Morgan (1982-3), Copying for Profit is Prohibited
 Copyright 1986 by Noah Morgan, All Rights Reserved.
@@ -23,8 +23,32 @@ var (
 	NothingHappened = fmt.Errorf("  Nothing Happened")
 	Inexperienced   = fmt.Errorf("  Nothing happens. You seem Inexperienced at this")
 	DidntWork       = fmt.Errorf("  It didn't work!")
+	NotASpell       = fmt.Errorf("  That's not a spell!")
+	PackFull        = fmt.Errorf("You can't carry anything else")
+	TooHeavy        = fmt.Errorf("You can't carry that much weight")
 )
 
+// Burden indicates how weighed down the character is by their pack
+type Burden int
+
+const (
+	// Unburdened the character moves normally
+	Unburdened Burden = iota
+	// Burdened the character is carrying more than their capacity
+	Burdened
+	// Overloaded the character is carrying far more than their capacity
+	Overloaded
+)
+
+var burdenName = map[Burden]string{
+	Unburdened: "unburdened",
+	Burdened:   "burdened",
+	Overloaded: "overloaded",
+}
+
+// String returns the displayable burden
+func (b Burden) String() string { return burdenName[b] }
+
 type action int
 
 const (
@@ -34,11 +58,10 @@ const (
 	ReadAction
 	TakeOffAction
 	QuaffAction
+	EatAction
 )
 
 const (
-	characterFG   = termbox.ColorRed
-	characterBG   = termbox.ColorRed
 	characterRune = '&'
 )
 
@@ -74,11 +97,11 @@ func (c *Character) Init(d int) {
 
 	if DEBUG { // Start with all the gold in a debug build
 		c.Stats.Gold = 10000000
-		c.inv.AddItem(&items.Gem{Stone: items.Diamond, Value: 1000}, c.Stats) // add a diamond for debugging
-		c.inv.AddItem(&items.Potion{ID: items.Forgetfulness}, c.Stats)        // add potion of forgetfulness for debugging
-		c.inv.AddItem(&items.Potion{ID: items.TreasureFinding}, c.Stats)      // add potion of treasurefinding for debugging
-		c.inv.AddItem(&items.Potion{ID: items.MonsterDetection}, c.Stats)     // add potion of MonsterDetection for debugging
-		c.inv.AddItem(&items.Potion{ID: items.Sleep}, c.Stats)                // add potion of Sleep for debugging
+		c.inv.AddItem(&items.Gem{Stone: items.Diamond, Quality: 100}, c.Stats) // add a diamond for debugging
+		c.inv.AddItem(&items.Potion{ID: items.Forgetfulness}, c.Stats)         // add potion of forgetfulness for debugging
+		c.inv.AddItem(&items.Potion{ID: items.TreasureFinding}, c.Stats)       // add potion of treasurefinding for debugging
+		c.inv.AddItem(&items.Potion{ID: items.MonsterDetection}, c.Stats)      // add potion of MonsterDetection for debugging
+		c.inv.AddItem(&items.Potion{ID: items.Sleep}, c.Stats)                 // add potion of Sleep for debugging
 	}
 
 	if d <= 0 { // 0 difficulty games the plaer starts with leather armor and dagger
@@ -100,13 +123,16 @@ func (c *Character) Rune() rune {
 }
 
 func (c *Character) Fg() termbox.Attribute {
-	return characterFG
+	return termbox.ColorDefault
 }
 
 func (c *Character) Bg() termbox.Attribute {
-	return characterBG
+	return termbox.ColorDefault
 }
 
+// Category implements the io.Categorized interface
+func (c *Character) Category() io.Category { return io.Player }
+
 // MoveCharacter the character in the given direction 1 space
 func (c *Character) MoveCharacter(d types.Direction) types.Coordinate {
 	c.loc = types.Move(c.loc, d)
@@ -130,9 +156,52 @@ func (c *Character) Wield(e rune) error {
 }
 
 // AddItem adds an item to the players inventory
-func (c *Character) AddItem(i items.Item) rune {
-	// TODO check if character can carry any more
-	return c.inv.AddItem(i, c.Stats)
+func (c *Character) AddItem(i items.Item) (rune, error) {
+	if err := c.CanCarry(i); err != nil {
+		return none, err
+	}
+	return c.inv.AddItem(i, c.Stats), nil
+}
+
+// CanCarry returns an error if the character is unable to add the item to their pack
+func (c *Character) CanCarry(i items.Item) error {
+	if !c.inv.Fits(i) {
+		return PackFull
+	}
+
+	// Items can be picked up until the character would be carrying twice their capacity
+	if c.PackWeight()+i.Weight() > c.Capacity()<<1 {
+		return TooHeavy
+	}
+
+	return nil
+}
+
+// PackWeight returns the weight of everything the character is carrying, including gold
+func (c *Character) PackWeight() int {
+	return c.inv.Weight() + int(c.Stats.Gold/1000)
+}
+
+// Capacity returns the weight the character can carry before being burdened
+func (c *Character) Capacity() int {
+	n := 25 + 5*(int(c.Stats.Str)+c.Stats.StrExtra)
+	if n < 25 {
+		return 25
+	}
+	return n
+}
+
+// Burden returns how weighed down the character currently is
+func (c *Character) Burden() Burden {
+	w, n := c.PackWeight(), c.Capacity()
+	switch {
+	case w > n+n>>1:
+		return Overloaded
+	case w > n:
+		return Burdened
+	default:
+		return Unburdened
+	}
 }
 
 // Item returns the item at the inventory slot
@@ -146,8 +215,48 @@ func (c *Character) DropItem(e rune) (items.Item, error) {
 }
 
 // Inventory returns a list of displayable inventory items
-func (c *Character) Inventory() []string {
-	return c.inv.List()
+func (c *Character) Inventory(k *items.Knowledge) []string {
+	return c.inv.List(k)
+}
+
+// InventoryIf returns a list of displayable inventory items that satisfy f
+func (c *Character) InventoryIf(k *items.Knowledge, f func(items.Item) bool) []string {
+	return c.inv.ListIf(k, f)
+}
+
+// IdentifyInventory identifies everything the character is carrying
+func (c *Character) IdentifyInventory(k *items.Knowledge) {
+	c.inv.Identify(k)
+}
+
+// Wearing returns the armor the character is wearing, or their shield if they aren't wearing armor
+func (c *Character) Wearing() items.Item {
+	return c.inv.Item(c.inv.Worn())
+}
+
+// EnchantWeapon changes the attribute of the wielded weapon by n
+func (c *Character) EnchantWeapon(n int) error {
+	return c.inv.Enchant(c.inv.weapon, n, c.Stats)
+}
+
+// EnchantArmor changes the attribute of the worn armor, or the shield if no armor is worn, by n
+func (c *Character) EnchantArmor(n int) error {
+	return c.inv.Enchant(c.inv.Worn(), n, c.Stats)
+}
+
+// DestroyWeapon destroys the wielded weapon
+func (c *Character) DestroyWeapon() items.Item {
+	return c.inv.Destroy(c.inv.weapon, c.Stats)
+}
+
+// DestroyArmor destroys the worn armor, or the shield if no armor is worn
+func (c *Character) DestroyArmor() items.Item {
+	return c.inv.Destroy(c.inv.Worn(), c.Stats)
+}
+
+// RemoveCurses lifts the curse from everything the character is carrying. Returns the number of items uncursed
+func (c *Character) RemoveCurses() int {
+	return c.inv.RemoveCurses()
 }
 
 // TakeOff removes a characters armor
@@ -156,19 +265,25 @@ func (c *Character) TakeOff() error {
 	return err
 }
 
+// Disarm has the character put down their weapon
+func (c *Character) Disarm() error {
+	_, err := c.inv.Disarm(none, c.Stats)
+	return err
+}
+
 // Wear has the character wear a weapon
 func (c *Character) Wear(e rune) error {
 	_, err := c.item(e, WearAction)
 	return err
 }
 
-// Read a scroll or book
-func (c *Character) Read(e rune) ([]string, error) {
+// Read a scroll or book. Returns the item that was read
+func (c *Character) Read(e rune) ([]string, items.Item, error) {
 	i, err := c.item(e, ReadAction)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	return i.(items.Readable).Read(c.Stats), nil
+	return i.(items.Readable).Read(c.Stats), i, nil
 }
 
 // item performs an item action on an item the character is carrying
@@ -186,11 +301,27 @@ func (c *Character) item(e rune, a action) (items.Item, error) {
 		return c.inv.TakeOff(e, c.Stats)
 	case QuaffAction:
 		return c.inv.Quaff(e, c.Stats)
+	case EatAction:
+		return c.inv.Eat(e, c.Stats)
 	default:
 		return nil, fmt.Errorf("Invalid action %v", a)
 	}
 }
 
+// FailChance returns the percent chance that casting the spell fails
+func (c *Character) FailChance(s items.Spell) int {
+	if !c.Stats.KnownSpells[s.Code] || int(c.Stats.Level) < s.Level() {
+		return 100
+	}
+
+	// Cast fails on a 1 in 23 roll, or when a d18 roll exceeds intelligence
+	fails := 17 - int(c.Stats.Intelligence)
+	if fails < 0 {
+		fails = 0
+	}
+	return 100 - 100*22*(18-fails)/(23*18)
+}
+
 // Cast handles the bookkeeping for a character casting a spell
 func (c *Character) Cast(s string) (*items.Spell, error) {
 	if c.Stats.Spells == 0 { // this should never happen, there's a guard before calls to this
@@ -199,7 +330,10 @@ func (c *Character) Cast(s string) (*items.Spell, error) {
 	}
 
 	// lookup spell and remove available spells from caster
-	spell := items.SpellFromCode(s)
+	spell, ok := items.LookupSpell(s)
+	if !ok {
+		return nil, NotASpell
+	}
 	c.Stats.Spells--
 
 	// check if caster knows this spell
@@ -213,7 +347,7 @@ func (c *Character) Cast(s string) (*items.Spell, error) {
 	}
 
 	// check if caster is high level enough to cast spell
-	if int(c.Stats.Level)*3+2 < spell.Id {
+	if int(c.Stats.Level) < spell.Level() {
 		return nil, Inexperienced
 	}
 
@@ -285,11 +419,30 @@ func (c *Character) Quaff(e rune) ([]string, items.PotionID, error) {
 	return s, pid, nil
 }
 
+// Eat food. Returns the messages from eating it
+func (c *Character) Eat(e rune) ([]string, error) {
+	i, err := c.item(e, EatAction)
+	if err != nil {
+		return nil, err
+	}
+	return i.(items.Food).Eat(c.Stats), nil
+}
+
 // Wielding returns the weapon the character is currently wielding
 func (c *Character) Wielding() items.Item {
 	return c.inv.Item(c.inv.weapon)
 }
 
+// CarryingPotion returns true if the character is carrying a potion of the given type
+func (c *Character) CarryingPotion(id items.PotionID) bool {
+	for _, item := range c.inv.inv {
+		if p, ok := item.(*items.Potion); ok && p.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
 // CarryingSpecial returns the special item if found in chars inventory
 func (c *Character) CarryingSpecial(t items.SpecialType) *items.Special {
 	for _, item := range c.inv.inv {
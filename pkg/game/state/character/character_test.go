@@ -0,0 +1,43 @@
+package character
+
+import (
+	"testing"
+
+	"github.com/thorfour/larn/pkg/game/state/items"
+)
+
+// TestCastInvalid ensures an invalid spell code doesn't use up a spell
+func TestCastInvalid(t *testing.T) {
+	c := new(Character)
+	c.Init(1)
+	spells := c.Stats.Spells
+
+	if _, err := c.Cast("xyz"); err != NotASpell {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	if c.Stats.Spells != spells {
+		t.Error("invalid spell used up a spell")
+	}
+}
+
+func TestFailChance(t *testing.T) {
+	c := new(Character)
+	c.Init(1)
+
+	mle := items.SpellFromCode("mle")
+	c.Stats.KnownSpells[mle.Code] = true
+	c.Stats.Intelligence = 18
+	if n := c.FailChance(mle); n != 5 {
+		t.Errorf("unexpected fail chance %v", n)
+	}
+
+	c.Stats.Intelligence = 8
+	if n := c.FailChance(mle); n != 53 {
+		t.Errorf("unexpected fail chance %v", n)
+	}
+
+	if n := c.FailChance(items.SpellFromCode("per")); n != 100 {
+		t.Errorf("unknown spell should always fail, got %v", n)
+	}
+}
@@ -3,7 +3,6 @@ package character
 import (
 	"fmt"
 	"sort"
-	"strings"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/thorfour/larn/pkg/game/state/items"
@@ -12,19 +11,41 @@ import (
 
 const none = '0'
 
+// MaxItems is the number of inventory slots, one for each letter a-z
+const MaxItems = 26
+
 // Inventory represents the characters inventory
 type Inventory struct {
 	shield rune
 	weapon rune
 	armor  rune
 	inv    map[rune]items.Item
-	unused []rune
+	stacks map[rune][]items.Item // identical items sharing a slot with the item in inv
+}
+
+// CursedError is returned when cursed gear refuses to be removed
+type CursedError struct {
+	Item items.Item // the cursed item
+}
+
+// Error implements the error interface
+func (c *CursedError) Error() string {
+	return "You can't, it appears to be cursed!"
+}
+
+// cursed returns a CursedError if the item is cursed, otherwise nil
+func cursed(item items.Item) error {
+	if c, ok := item.(items.Cursable); ok && c.IsCursed() {
+		return &CursedError{Item: item}
+	}
+	return nil
 }
 
 // NewInventory returns a new initialized inventory struct
 func NewInventory() *Inventory {
 	i := new(Inventory)
 	i.inv = make(map[rune]items.Item)
+	i.stacks = make(map[rune][]items.Item)
 	i.shield = none
 	i.weapon = none
 	i.armor = none
@@ -33,45 +54,150 @@ func NewInventory() *Inventory {
 }
 
 // List returns all the items in the inventory
-func (i *Inventory) List() []string {
-	var ret []string
+func (i *Inventory) List(k *items.Knowledge) []string {
+	return i.ListIf(k, nil)
+}
+
+// ListIf returns all the items in the inventory that satisfy f, grouped by category and sorted by letter. A nil f lists every item
+func (i *Inventory) ListIf(k *items.Knowledge, f func(items.Item) bool) []string {
+	var slots []rune
 	for r := range i.inv {
+		if f != nil && !f(i.inv[r]) {
+			continue
+		}
+		slots = append(slots, r)
+	}
+
+	sort.Slice(slots, func(a, b int) bool {
+		ca, cb := category(i.inv[slots[a]]), category(i.inv[slots[b]])
+		if ca != cb {
+			return ca < cb
+		}
+		return slots[a] < slots[b]
+	})
+
+	var ret []string
+	for _, r := range slots {
+		str := fmt.Sprintf("%s) %s", string(r), i.inv[r].String(k))
+		if n := i.Count(r); n > 1 {
+			str += fmt.Sprintf(" (x%v)", n)
+		}
 		switch r {
 		case i.weapon:
-			ret = append(ret, fmt.Sprintf("%s) %s %s", string(r), i.inv[r], "(weapon in hand)"))
+			str += " (weapon in hand)"
 		case i.shield:
 			fallthrough
 		case i.armor:
-			ret = append(ret, fmt.Sprintf("%s) %s %s", string(r), i.inv[r], "(being worn)"))
-		default:
-			ret = append(ret, fmt.Sprintf("%s) %s", string(r), i.inv[r].String()))
+			str += " (being worn)"
 		}
+		ret = append(ret, str)
 	}
 
-	// NOTE: since maps return random order, sort the return slice here
-	sort.Slice(ret, func(i, j int) bool {
-		return strings.Split(ret[i], " ")[0] < strings.Split(ret[j], " ")[0]
-	})
-
 	log.WithField("items", ret).Debug("Inventory")
 
 	return ret
 }
 
-// AddItem adds a new item the the inventory and returns its assigned rune
+// category returns the listing order of the item's category: weapons, armor, rings, potions, scrolls, food, gems then special items
+func category(item items.Item) int {
+	switch item.(type) {
+	case *items.Shield, items.Armor:
+		return 1
+	case items.Weapon:
+		return 0
+	case *items.Ring:
+		return 2
+	case items.Quaffable:
+		return 3
+	case items.Readable:
+		return 4
+	case items.Food:
+		return 5
+	case *items.Gem:
+		return 6
+	default:
+		return 7
+	}
+}
+
+// Count returns the number of items in slot r
+func (i *Inventory) Count(r rune) int {
+	if _, ok := i.inv[r]; !ok {
+		return 0
+	}
+	return 1 + len(i.stacks[r])
+}
+
+// AddItem adds a new item the the inventory and returns its assigned rune. Returns none if the inventory is full
 func (i *Inventory) AddItem(item items.Item, s *stats.Stats) rune {
-	slot := 'a'
-	if len(i.unused) == 0 {
-		slot += rune(len(i.inv))
-	} else {
-		slot = i.unused[0]
-		i.unused = i.unused[1:]
+	// Identical items share a slot
+	if r := i.stackSlot(item); r != none {
+		i.stacks[r] = append(i.stacks[r], item)
+		item.PickUp(s)
+		return r
 	}
 
-	i.inv[slot] = item
-	item.PickUp(s)
+	// Use the first free slot
+	for slot := 'a'; slot < 'a'+MaxItems; slot++ {
+		if _, ok := i.inv[slot]; ok {
+			continue
+		}
+
+		i.inv[slot] = item
+		item.PickUp(s)
+		return slot
+	}
+
+	return none
+}
+
+// stackSlot returns the slot an item can be stacked onto, none if it doesn't stack
+func (i *Inventory) stackSlot(item items.Item) rune {
+	st, ok := item.(items.Stackable)
+	if !ok {
+		return none
+	}
+	for r, other := range i.inv {
+		if st.Stacks(other) {
+			return r
+		}
+	}
+	return none
+}
+
+// remove takes a single item out of slot r. Items stacked in the slot are removed before the slot is freed
+func (i *Inventory) remove(r rune) items.Item {
+	if n := len(i.stacks[r]); n > 0 {
+		item := i.stacks[r][n-1]
+		i.stacks[r] = i.stacks[r][:n-1]
+		if n == 1 {
+			delete(i.stacks, r)
+		}
+		return item
+	}
+
+	item := i.inv[r]
+	delete(i.inv, r)
+	return item
+}
 
-	return slot
+// Full returns true if every inventory slot is in use
+func (i *Inventory) Full() bool {
+	return len(i.inv) >= MaxItems
+}
+
+// Fits returns true if there is a slot for the item, either a free one or one it stacks onto
+func (i *Inventory) Fits(item items.Item) bool {
+	return !i.Full() || i.stackSlot(item) != none
+}
+
+// Weight returns the combined weight of every item in the inventory
+func (i *Inventory) Weight() int {
+	w := 0
+	for r, item := range i.inv {
+		w += item.Weight() * i.Count(r)
+	}
+	return w
 }
 
 // Drop an item. Returns the item that was dropped. Caller should call necessary drop func
@@ -82,20 +208,24 @@ func (i *Inventory) Drop(r rune, s *stats.Stats) (items.Item, error) {
 	}
 
 	// Remove from wear/wield
+	var err error
 	switch r {
-	case i.shield:
-		fallthrough
 	case i.weapon:
-		i.Disarm(r, s)
-	case i.armor:
-		i.TakeOff(r, s)
+		_, err = i.Disarm(r, s)
+	case i.shield, i.armor:
+		_, err = i.TakeOff(r, s)
+	default:
+		// Rings and belts work as long as they're carried, so a cursed one won't leave the pack
+		switch item.(type) {
+		case *items.Ring, *items.Belt:
+			err = cursed(item)
+		}
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	delete(i.inv, r)
-	i.unused = append(i.unused, r)
-	sort.Slice(i.unused, func(a, b int) bool {
-		return i.unused[a] < i.unused[b]
-	})
+	item = i.remove(r)
 	item.Drop(s)
 
 	return item, nil
@@ -115,6 +245,21 @@ func (i *Inventory) Wield(r rune, s *stats.Stats) (items.Weapon, error) {
 
 	// TODO check if two handed sword and shield
 
+	if r == i.shield {
+		return nil, fmt.Errorf("You're wearing that")
+	}
+
+	if r == i.weapon {
+		return nil, fmt.Errorf("You're already wielding that")
+	}
+
+	// Put down the current weapon first
+	if i.weapon != none {
+		if _, err := i.Disarm(i.weapon, s); err != nil {
+			return nil, err
+		}
+	}
+
 	// Mark this weapn as being wielded
 	i.weapon = r
 	w.Wield(s)
@@ -129,37 +274,68 @@ func (i *Inventory) Wear(r rune, s *stats.Stats) (items.Armor, error) {
 		return nil, fmt.Errorf("You don't have item %s", string(r))
 	}
 
-	if i.armor != none {
-		return nil, fmt.Errorf("You're already wearing armor")
-	}
-
 	a, ok := item.(items.Armor)
 	if !ok {
 		return nil, fmt.Errorf("You can't wear that!")
 	}
 
+	// Shields are worn alongside armor
+	if _, ok := item.(*items.Shield); ok {
+		if i.shield != none {
+			return nil, fmt.Errorf("You're already wearing a shield")
+		}
+		if r == i.weapon {
+			return nil, fmt.Errorf("You're wielding that")
+		}
+		i.shield = r
+		a.Wear(s)
+		return a, nil
+	}
+
+	if i.armor != none {
+		return nil, fmt.Errorf("You're already wearing armor")
+	}
+
 	i.armor = r
 	a.Wear(s)
 
 	return a, nil
 }
 
-// TakeOff armor
-func (i *Inventory) TakeOff(_ rune, s *stats.Stats) (items.Armor, error) {
-	if i.armor == none {
+// TakeOff the worn item at slot r. Takes off the armor, or the shield when there's no armor, if r isn't worn
+func (i *Inventory) TakeOff(r rune, s *stats.Stats) (items.Armor, error) {
+	if r == none || r != i.shield {
+		r = i.Worn()
+	}
+	if r == none {
 		return nil, fmt.Errorf("You're not wearing anything")
 	}
 
-	a, ok := i.inv[i.armor].(items.Armor)
+	a, ok := i.inv[r].(items.Armor)
 	if !ok {
 		log.WithField("item", a).Error("not wearing armor")
 	}
-	i.armor = none
+	if err := cursed(a); err != nil {
+		return nil, err
+	}
+	if r == i.shield {
+		i.shield = none
+	} else {
+		i.armor = none
+	}
 	a.TakeOff(s)
 
 	return a, nil
 }
 
+// Worn returns the slot of the worn armor, or the worn shield if there's no armor. Returns none if nothing is worn
+func (i *Inventory) Worn() rune {
+	if i.armor != none {
+		return i.armor
+	}
+	return i.shield
+}
+
 // Read a book or scroll. Caller should call Read() on returned item
 func (i *Inventory) Read(r rune, s *stats.Stats) (items.Item, error) {
 	item, ok := i.inv[r]
@@ -171,8 +347,7 @@ func (i *Inventory) Read(r rune, s *stats.Stats) (items.Item, error) {
 		return nil, fmt.Errorf("You can't read that!")
 	}
 
-	delete(i.inv, r)
-	return item, nil
+	return i.remove(r), nil
 }
 
 // Disarm wielded weapon
@@ -185,6 +360,9 @@ func (i *Inventory) Disarm(_ rune, s *stats.Stats) (items.Weapon, error) {
 	if !ok {
 		log.WithField("item", w).Error("not wielding weapon")
 	}
+	if err := cursed(w); err != nil {
+		return nil, err
+	}
 	i.weapon = none
 	w.Disarm(s)
 
@@ -196,6 +374,87 @@ func (i *Inventory) Item(e rune) items.Item {
 	return i.inv[e]
 }
 
+// Identify identifies every item in the inventory
+func (i *Inventory) Identify(k *items.Knowledge) {
+	for _, item := range i.inv {
+		k.Identify(item)
+	}
+}
+
+// Enchant changes the attribute of the item at slot r by n. Worn and wielded items are re-equipped so the stats stay in sync
+func (i *Inventory) Enchant(r rune, n int, s *stats.Stats) error {
+	item, ok := i.inv[r]
+	if !ok {
+		return fmt.Errorf("You don't have item %s", string(r))
+	}
+
+	a, ok := item.(items.Attributable)
+	if !ok {
+		return fmt.Errorf("Nothing happens")
+	}
+
+	i.unequip(r, item, s)
+	a.ResetAttr(a.Attr() + n)
+	i.equip(r, item, s)
+
+	return nil
+}
+
+// Destroy removes the item at slot r from the inventory, even if it's cursed. Returns the destroyed item
+func (i *Inventory) Destroy(r rune, s *stats.Stats) items.Item {
+	item, ok := i.inv[r]
+	if !ok {
+		return nil
+	}
+
+	i.unequip(r, item, s)
+	switch r {
+	case i.weapon:
+		i.weapon = none
+	case i.armor:
+		i.armor = none
+	case i.shield:
+		i.shield = none
+	}
+	i.remove(r)
+
+	return item
+}
+
+// unequip removes every stat the item at slot r provides
+func (i *Inventory) unequip(r rune, item items.Item, s *stats.Stats) {
+	item.Drop(s)
+	switch r {
+	case i.weapon:
+		item.(items.Weapon).Disarm(s)
+	case i.armor, i.shield:
+		item.(items.Armor).TakeOff(s)
+	}
+}
+
+// equip re-applies every stat the item at slot r provides
+func (i *Inventory) equip(r rune, item items.Item, s *stats.Stats) {
+	item.PickUp(s)
+	switch r {
+	case i.weapon:
+		item.(items.Weapon).Wield(s)
+	case i.armor, i.shield:
+		item.(items.Armor).Wear(s)
+	}
+}
+
+// RemoveCurses lifts the curse from every item in the inventory. Returns the number of items uncursed
+func (i *Inventory) RemoveCurses() int {
+	n := 0
+	for _, item := range i.inv {
+		if c, ok := item.(items.Cursable); ok && c.IsCursed() {
+			c.Uncurse()
+			n++
+		}
+	}
+	return n
+}
+
 // Quaff ensures the item is Quaffable. Removes item from inventory, Quaff should be called on item
 func (i *Inventory) Quaff(r rune, s *stats.Stats) (items.Quaffable, error) {
 	item, ok := i.inv[r]
@@ -203,11 +462,23 @@ func (i *Inventory) Quaff(r rune, s *stats.Stats) (items.Quaffable, error) {
 		return nil, fmt.Errorf("You don't have item %s", string(r))
 	}
 
-	p, ok := item.(items.Quaffable)
-	if !ok {
+	if _, ok := item.(items.Quaffable); !ok {
 		return nil, fmt.Errorf("You can't quaff that!")
 	}
 
-	delete(i.inv, r)
-	return p, nil
+	return i.remove(r).(items.Quaffable), nil
+}
+
+// Eat ensures the item is Food. Removes item from inventory, Eat should be called on item
+func (i *Inventory) Eat(r rune, s *stats.Stats) (items.Food, error) {
+	item, ok := i.inv[r]
+	if !ok {
+		return nil, fmt.Errorf("You don't have item %s", string(r))
+	}
+
+	if _, ok := item.(items.Food); !ok {
+		return nil, fmt.Errorf("You can't eat that!")
+	}
+
+	return i.remove(r).(items.Food), nil
 }
@@ -35,7 +35,7 @@ func TestDropItems(t *testing.T) {
 	i.Drop('a', s)
 
 	// Ensure the remaining item is item B
-	r := i.List()
+	r := i.List(nil)
 	if len(r) != 1 {
 		t.Error("unexpected number of results")
 	}
@@ -56,7 +56,7 @@ func TestItemOrder(t *testing.T) {
 	i.Drop('c', s)
 
 	// Ensure the remaining item is item B
-	r := i.List()
+	r := i.List(nil)
 	for i := range r {
 		switch getIndex(r[i]) {
 		case "a)":
@@ -84,3 +84,231 @@ func TestInit(t *testing.T) {
 		t.Error("failed to wear", err)
 	}
 }
+
+// TestFullInventory ensures an inventory can't hold more than one item per letter
+func TestFullInventory(t *testing.T) {
+	i := NewInventory()
+	s := new(stats.Stats)
+
+	for n := 0; n < MaxItems; n++ {
+		if r := i.AddItem(items.GetNewWeapon(items.Dagger, 0), s); r == none {
+			t.Fatalf("unable to add item %v", n)
+		}
+	}
+
+	if !i.Full() {
+		t.Error("inventory should be full")
+	}
+
+	if r := i.AddItem(items.GetNewWeapon(items.Dagger, 0), s); r != none {
+		t.Errorf("item added to full inventory at %s", string(r))
+	}
+}
+
+// TestSlotReuse ensures consumed items free up their slot without overwriting others
+func TestSlotReuse(t *testing.T) {
+	i := NewInventory()
+	s := new(stats.Stats)
+
+	i.AddItem(&items.Potion{ID: items.Water}, s)
+	i.AddItem(&items.Potion{ID: items.Healing}, s)
+	i.AddItem(&items.Potion{ID: items.Blindness}, s)
+	if _, err := i.Quaff('b', s); err != nil {
+		t.Fatal("failed to quaff", err)
+	}
+
+	if r := i.AddItem(&items.Potion{ID: items.Sleep}, s); r != 'b' {
+		t.Errorf("expected slot b to be reused, got %s", string(r))
+	}
+
+	if len(i.List(nil)) != 3 {
+		t.Error("unexpected number of items")
+	}
+}
+
+// TestBurden ensures a character becomes burdened when carrying more than their capacity
+func TestBurden(t *testing.T) {
+	c := new(Character)
+	c.Init(1)
+
+	if c.Burden() != Unburdened {
+		t.Fatal("new character shouldn't be burdened")
+	}
+
+	for c.PackWeight() <= c.Capacity() {
+		if _, err := c.AddItem(items.NewArmor(items.PlateArmor, 0)); err != nil {
+			t.Fatal("unable to add armor", err)
+		}
+	}
+
+	if c.Burden() != Burdened {
+		t.Errorf("expected character to be burdened, got %s", c.Burden())
+	}
+
+	for {
+		if _, err := c.AddItem(items.NewArmor(items.PlateArmor, 0)); err != nil {
+			if err != TooHeavy {
+				t.Error("unexpected error", err)
+			}
+			break
+		}
+	}
+
+	if c.PackWeight() > c.Capacity()<<1 {
+		t.Error("character carrying more than the limit")
+	}
+}
+
+func TestCursedGear(t *testing.T) {
+	i := NewInventory()
+	s := new(stats.Stats)
+
+	w := items.GetNewWeapon(items.Dagger, 0)
+	w.Curse()
+	r := i.AddItem(w, s)
+	if _, err := i.Wield(r, s); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := i.Disarm(r, s); err == nil {
+		t.Error("cursed weapon was disarmed")
+	}
+
+	if _, err := i.Wield(i.AddItem(items.GetNewWeapon(items.Spear, 0), s), s); err == nil {
+		t.Error("cursed weapon was replaced")
+	}
+
+	if _, err := i.Drop(r, s); err == nil {
+		t.Error("cursed weapon was dropped")
+	}
+
+	ring := &items.Ring{Type: items.Regen}
+	ring.Curse()
+	if _, err := i.Drop(i.AddItem(ring, s), s); err == nil {
+		t.Error("cursed ring was dropped")
+	}
+
+	if i.RemoveCurses() != 2 {
+		t.Error("unexpected number of curses removed")
+	}
+
+	if _, err := i.Drop(r, s); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestEnchant(t *testing.T) {
+	i := NewInventory()
+	s := new(stats.Stats)
+
+	w := items.GetNewWeapon(items.Dagger, 0)
+	w.ResetAttr(0)
+	r := i.AddItem(w, s)
+	if _, err := i.Wield(r, s); err != nil {
+		t.Fatal(err)
+	}
+	wc := s.Wc
+
+	i.Enchant(r, 2, s)
+	if w.Attr() != 2 || s.Wc != wc+2 {
+		t.Errorf("unexpected weapon class %v after enchanting", s.Wc)
+	}
+
+	i.Enchant(r, -3, s)
+	if w.Attr() != -1 || s.Wc != wc-1 {
+		t.Errorf("unexpected weapon class %v after dulling", s.Wc)
+	}
+
+	w.Curse()
+	if i.Destroy(r, s) != w || s.Wc != 0 {
+		t.Error("cursed weapon wasn't destroyed")
+	}
+}
+
+func TestEnchantShield(t *testing.T) {
+	i := NewInventory()
+	s := new(stats.Stats)
+
+	a := items.NewArmor(items.Leather, 0)
+	a.ResetAttr(0)
+	sh := &items.Shield{}
+	sh.ResetAttr(0)
+	ra := i.AddItem(a, s)
+	r := i.AddItem(sh, s)
+	if _, err := i.Wear(r, s); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := i.Wear(ra, s); err != nil {
+		t.Fatal(err)
+	}
+	ac := s.Ac
+
+	i.Enchant(r, 2, s)
+	if sh.Attr() != 2 || s.Ac != ac+2 {
+		t.Errorf("unexpected armor class %v after enchanting worn shield", s.Ac)
+	}
+
+	if _, err := i.TakeOff(none, s); err != nil {
+		t.Fatal(err)
+	}
+	if i.Worn() != r {
+		t.Fatal("shield isn't worn after taking off armor")
+	}
+
+	if i.Destroy(r, s) != sh || s.Ac != 0 || i.Worn() != none {
+		t.Errorf("unexpected armor class %v after destroying worn shield", s.Ac)
+	}
+}
+
+func TestStacking(t *testing.T) {
+	i := NewInventory()
+	s := new(stats.Stats)
+
+	for n := 0; n < MaxItems; n++ {
+		i.AddItem(items.GetNewWeapon(items.Dagger, 0), s)
+	}
+	i.Drop('c', s)
+
+	if r := i.AddItem(&items.Potion{ID: items.Healing}, s); r != 'c' {
+		t.Fatalf("unexpected slot %s", string(r))
+	}
+
+	if !i.Fits(&items.Potion{ID: items.Healing}) || i.Fits(&items.Potion{ID: items.Sleep}) {
+		t.Error("only identical potions should fit in a full pack")
+	}
+
+	if r := i.AddItem(&items.Potion{ID: items.Healing}, s); r != 'c' {
+		t.Errorf("identical potion wasn't stacked, got %s", string(r))
+	}
+
+	if i.Count('c') != 2 {
+		t.Errorf("unexpected stack size %v", i.Count('c'))
+	}
+
+	if _, err := i.Quaff('c', s); err != nil {
+		t.Fatal(err)
+	}
+
+	if i.Count('c') != 1 {
+		t.Error("quaffing should only consume one potion from the stack")
+	}
+}
+
+func TestListOrder(t *testing.T) {
+	i := NewInventory()
+	s := new(stats.Stats)
+
+	i.AddItem(&items.Scroll{ID: items.Identify}, s)
+	i.AddItem(&items.Potion{ID: items.Healing}, s)
+	i.AddItem(items.GetNewWeapon(items.Dagger, 0), s)
+	i.AddItem(&items.Potion{ID: items.Sleep}, s)
+
+	expected := []string{"c)", "b)", "d)", "a)"}
+	for n := 0; n < 10; n++ { // map ordering is random, ensure the order is stable
+		for j, l := range i.List(nil) {
+			if getIndex(l) != expected[j] {
+				t.Fatalf("unexpected order %v", i.List(nil))
+			}
+		}
+	}
+}
@@ -1,17 +1,24 @@
 package conditions
 
-type condition int
+import (
+	"sort"
+
+	"github.com/thorfour/larn/pkg/game/state/stats"
+)
+
+// Condition is a timed effect on the character
+type Condition int
 
 const (
-	// Blindness means the player no longer reveals objects when encountering them
-	Blindness condition = iota
-	// Confusion character is confuesd
+	// Blindness means the player no longer reveals objects when encountering them and can't see monsters
+	Blindness Condition = iota
+	// Confusion character is confuesd, movement stumbles in random directions and directed spells fail
 	Confusion
 	// Heroic status
 	Heroic
 	// GiantStrength spell
 	GiantStrength
-	// FireResistance resistance to hell hounds and other fire sources
+	// FireResistance resistance to hell hound and dragon breath, fireballs and other fire sources
 	FireResistance
 	// HalfDamage player only deals half damange
 	HalfDamage
@@ -33,74 +40,156 @@ const (
 	Invisiblity
 	// CharmMonsters monsters are more likely to be charmed
 	CharmMonsters
-	// Cancellation TODO
+	// Cancellation negates the special attacks of monsters
 	Cancellation
 	// HasteSelf increases character speed
 	HasteSelf
 	// ScareMonster makes them scared of you
 	ScareMonster
+	// AltarProtection the gods have heard the players prayers
+	AltarProtection
 )
 
+var conditionName = map[Condition]string{
+	Blindness:         "Blind",
+	Confusion:         "Confused",
+	Heroic:            "Heroic",
+	GiantStrength:     "GiantStr",
+	FireResistance:    "FireRes",
+	HalfDamage:        "HalfDmg",
+	SeeInvisible:      "SeeInvis",
+	HoldMonsters:      "Hold",
+	TimeStop:          "TimeStop",
+	GlobeOfInvul:      "Globe",
+	SpellOfStrength:   "Strength",
+	SpellOfDexterity:  "Dexterity",
+	SpellOfProtection: "Protect",
+	Invisiblity:       "Invisible",
+	CharmMonsters:     "Charm",
+	Cancellation:      "Cancel",
+	HasteSelf:         "Haste",
+	ScareMonster:      "Scare",
+	AltarProtection:   "Altar",
+}
+
+// String returns the short display name of the condition
+func (c Condition) String() string {
+	return conditionName[c]
+}
+
+// expire undoes the stat changes a condition made when it was applied. Magnitude is the size of the change
+var expire = map[Condition]func(s *stats.Stats, magnitude int){
+	Heroic: func(s *stats.Stats, n int) {
+		s.Cha -= uint(n)
+		s.Wisdom -= uint(n)
+		s.Con -= uint(n)
+		s.Dex -= uint(n)
+		s.Str -= uint(n)
+		s.Intelligence -= uint(n)
+	},
+	GiantStrength:     func(s *stats.Stats, n int) { s.StrExtra -= n },
+	SpellOfStrength:   func(s *stats.Stats, n int) { s.Str -= uint(n) },
+	SpellOfDexterity:  func(s *stats.Stats, n int) { s.Dex -= uint(n) },
+	SpellOfProtection: func(s *stats.Stats, n int) { s.Ac -= n },
+	GlobeOfInvul:      func(s *stats.Stats, n int) { s.Ac -= n },
+	AltarProtection:   func(s *stats.Stats, n int) { s.Ac -= n },
+}
+
+// Effect is a single active condition
+type Effect struct {
+	Condition Condition `json:"condition"`
+	Turns     int       `json:"turns"`     // turns remaining
+	Magnitude int       `json:"magnitude"` // size of the stat change the condition made, undone when it wears off
+}
+
 // ActiveConditions represents all active conditions a character might have
 type ActiveConditions struct {
-	active map[condition]func(int)
+	Effects map[Condition]*Effect `json:"effects"`
 }
 
 // New returns a new active conditions struct
 func New() *ActiveConditions {
 	a := new(ActiveConditions)
-	a.active = make(map[condition]func(int))
+	a.Effects = make(map[Condition]*Effect)
 	return a
 }
 
 // EffectActive returns true if the given condition is active
-func (a *ActiveConditions) EffectActive(c condition) bool {
-	_, ok := a.active[c]
+func (a *ActiveConditions) EffectActive(c Condition) bool {
+	_, ok := a.Effects[c]
 	return ok
 }
 
-// DecayAll calls the decay function on all active conditions
-func (a *ActiveConditions) DecayAll() {
-	for i := range a.active {
-		a.active[i](0)
+// Turns returns the number of turns remaining on the condition, 0 if it isn't active
+func (a *ActiveConditions) Turns(c Condition) int {
+	if e, ok := a.Effects[c]; ok {
+		return e.Turns
 	}
+	return 0
 }
 
-// Decay calls the decay function on a single condition
-func (a *ActiveConditions) Decay(c condition) {
-	a.active[c](0)
+// Magnitude returns the magnitude of the condition, 0 if it isn't active
+func (a *ActiveConditions) Magnitude(c Condition) int {
+	if e, ok := a.Effects[c]; ok {
+		return e.Magnitude
+	}
+	return 0
+}
+
+// Active returns every active condition in condition order
+func (a *ActiveConditions) Active() []Effect {
+	var active []Effect
+	for _, e := range a.Effects {
+		active = append(active, *e)
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].Condition < active[j].Condition })
+	return active
+}
+
+// DecayAll decays all active conditions by a turn
+func (a *ActiveConditions) DecayAll(s *stats.Stats) {
+	for c := range a.Effects {
+		a.Decay(c, s)
+	}
+}
+
+// Decay decays a single condition by a turn. A condition that runs out undoes its stat changes and is removed
+func (a *ActiveConditions) Decay(c Condition, s *stats.Stats) {
+	e, ok := a.Effects[c]
+	if !ok {
+		return
+	}
+
+	e.Turns--
+	if e.Turns <= 0 {
+		if f, ok := expire[c]; ok {
+			f(s, e.Magnitude)
+		}
+		a.Remove(c)
+	}
 }
 
 // Refresh adds time onto a given condition, adds a new condition if the condition doesn't exist
-func (a *ActiveConditions) Refresh(c condition, n int, decay func()) {
-	if _, ok := a.active[c]; ok {
-		a.active[c](n)
+func (a *ActiveConditions) Refresh(c Condition, n, magnitude int) {
+	if e, ok := a.Effects[c]; ok {
+		e.Turns += n
 		return
 	}
 
 	// Add condition
-	a.Add(c, n, decay)
+	a.Add(c, n, magnitude)
 }
 
-// Remove an active condition
-func (a ActiveConditions) Remove(c condition) {
-	delete(a.active, c)
+// Remove an active condition without undoing its stat changes
+func (a *ActiveConditions) Remove(c Condition) {
+	delete(a.Effects, c)
 }
 
-// Add an active condition with the given decay function
-func (a ActiveConditions) Add(c condition, dur int, decay func()) {
-	a.active[c] = func(refresh int) {
-		if refresh != 0 { // refresh instead of decay
-			dur += refresh
-			return
-		}
-
-		dur--
-		if dur == 0 {
-			if decay != nil {
-				decay() // execure the decay func
-			}
-			a.Remove(c)
-		}
+// Add an active condition that lasts dur turns. Replaces the duration of an active condition
+func (a *ActiveConditions) Add(c Condition, dur, magnitude int) {
+	if e, ok := a.Effects[c]; ok {
+		e.Turns = dur
+		return
 	}
+	a.Effects[c] = &Effect{Condition: c, Turns: dur, Magnitude: magnitude}
 }
@@ -0,0 +1,65 @@
+package conditions
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/thorfour/larn/pkg/game/state/stats"
+)
+
+func TestDecay(t *testing.T) {
+	a := New()
+	s := &stats.Stats{Ac: 5}
+
+	s.Ac += 2
+	a.Add(SpellOfProtection, 2, 2)
+	a.Refresh(SpellOfProtection, 1, 2)
+	if a.Turns(SpellOfProtection) != 3 || a.Magnitude(SpellOfProtection) != 2 {
+		t.Fatalf("unexpected effect %v", a.Effects[SpellOfProtection])
+	}
+
+	for i := 0; i < 3; i++ {
+		a.DecayAll(s)
+	}
+
+	if a.EffectActive(SpellOfProtection) {
+		t.Error("effect should have worn off")
+	}
+	if s.Ac != 5 {
+		t.Errorf("unexpected armor class %v after protection wore off", s.Ac)
+	}
+}
+
+func TestActive(t *testing.T) {
+	a := New()
+	a.Add(HasteSelf, 10, 0)
+	a.Add(Blindness, 20, 0)
+
+	active := a.Active()
+	if len(active) != 2 || active[0].Condition != Blindness || active[1].Condition != HasteSelf {
+		t.Fatalf("unexpected active effects %v", active)
+	}
+
+	if active[1].Condition.String() != "Haste" || active[1].Turns != 10 {
+		t.Errorf("unexpected effect %v", active[1])
+	}
+}
+
+func TestPersist(t *testing.T) {
+	a := New()
+	a.Add(Heroic, 250, 11)
+
+	b, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := New()
+	if err := json.Unmarshal(b, restored); err != nil {
+		t.Fatal(err)
+	}
+
+	if restored.Turns(Heroic) != 250 || restored.Magnitude(Heroic) != 11 {
+		t.Errorf("unexpected restored effect %v", restored.Effects[Heroic])
+	}
+}
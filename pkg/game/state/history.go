@@ -0,0 +1,82 @@
+package state
+
+// Category groups messages so they can be filtered or colored
+type Category int
+
+const (
+	// System messages about the game itself, the default category
+	System Category = iota
+	// Combat messages from fighting monsters
+	Combat
+	// Item messages from finding and using items
+	Item
+)
+
+// String returns the name of the category
+func (c Category) String() string {
+	switch c {
+	case Combat:
+		return "combat"
+	case Item:
+		return "item"
+	default:
+		return "system"
+	}
+}
+
+// Message is a single entry in the message history
+type Message struct {
+	Text     string   `json:"text"`
+	Turn     uint     `json:"turn"` // turn the message was logged on
+	Category Category `json:"category"`
+}
+
+// Log adds the string to the statlog as a system message
+func (s *State) Log(str string) {
+	s.LogAs(System, str)
+}
+
+// LogAs adds the string to the statlog under the given category
+func (s *State) LogAs(c Category, str string) {
+	s.StatLog = s.StatLog.add(str)
+	s.History = append(s.History, Message{Text: str, Turn: s.timeUsed, Category: c})
+	s.unread++
+}
+
+// LogReplace replaces the most recent statlog entry, used to echo input typed at a prompt
+func (s *State) LogReplace(str string) {
+	if len(s.History) == 0 {
+		s.Log(str)
+		return
+	}
+	s.StatLog[len(s.StatLog)-1] = str
+	s.History[len(s.History)-1].Text = str
+}
+
+// Messages returns the message history in the given categories, every message if none are given
+func (s *State) Messages(categories ...Category) []Message {
+	if len(categories) == 0 {
+		return s.History
+	}
+
+	var msgs []Message
+	for _, m := range s.History {
+		for _, c := range categories {
+			if m.Category == c {
+				msgs = append(msgs, m)
+				break
+			}
+		}
+	}
+	return msgs
+}
+
+// Unread returns the messages logged since the last call to MarkRead
+func (s *State) Unread() []Message {
+	return s.History[len(s.History)-s.unread:]
+}
+
+// MarkRead marks every message as read
+func (s *State) MarkRead() {
+	s.unread = 0
+}
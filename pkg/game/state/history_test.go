@@ -0,0 +1,41 @@
+package state
+
+import "testing"
+
+// TestHistory ensures every message is kept with its turn and category
+func TestHistory(t *testing.T) {
+	s := New(0)
+	s.MarkRead()
+
+	for i := 0; i < 2*logLength; i++ {
+		s.LogAs(Combat, "You hit the bat")
+		s.update()
+	}
+	s.LogAs(Item, "You drop:")
+	s.LogReplace("You drop: a dagger")
+
+	if len(s.Unread()) != 2*logLength+1 {
+		t.Errorf("unexpected number of unread messages %v", len(s.Unread()))
+	}
+	s.MarkRead()
+	if len(s.Unread()) != 0 {
+		t.Error("messages weren't marked read")
+	}
+
+	combat := s.Messages(Combat)
+	if len(combat) != 2*logLength {
+		t.Fatalf("unexpected number of combat messages %v", len(combat))
+	}
+	if combat[0].Turn >= combat[len(combat)-1].Turn {
+		t.Error("messages weren't stamped with the turn")
+	}
+
+	items := s.Messages(Item)
+	if len(items) != 1 || items[0].Text != "You drop: a dagger" {
+		t.Errorf("unexpected item messages %v", items)
+	}
+
+	if len(s.Messages()) != len(s.History) {
+		t.Error("unfiltered messages should include the whole history")
+	}
+}
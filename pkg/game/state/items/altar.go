@@ -1,5 +1,7 @@
 package items
 
+import "github.com/thorfour/larn/pkg/io"
+
 const (
 	altarRune = 'A'
 )
@@ -17,7 +19,10 @@ func (a *Altar) Rune() rune {
 	return invisibleRune
 }
 
+// Category implements the io.Categorized interface
+func (a *Altar) Category() io.Category { return io.Feature }
+
 // Log implementes the Loggable interface
 func (a *Altar) Log() string {
-	return "There is a Holy Altar here!"
+	return "There is a Holy Altar here! (p to pray, d. to contribute)"
 }
@@ -2,9 +2,9 @@ package items
 
 import (
 	"math/rand"
-	"strconv"
 
 	"github.com/thorfour/larn/pkg/game/state/stats"
+	"github.com/thorfour/larn/pkg/io"
 )
 
 // ArmorType indicates the type of armor
@@ -43,6 +43,18 @@ var armorBase = map[ArmorType]int{
 	StainlessPlateArmor: 12,
 }
 
+// Map of all the armor weights
+var armorWeight = map[ArmorType]int{
+	Leather:             8,
+	StuddedLeather:      15,
+	RingMail:            20,
+	ChainMail:           23,
+	SplintMail:          26,
+	PlateMail:           35,
+	PlateArmor:          40,
+	StainlessPlateArmor: 40,
+}
+
 // Map of all the displayable armor names
 var armorName = map[ArmorType]string{
 	Leather:             "leather",
@@ -59,6 +71,7 @@ var armorName = map[ArmorType]string{
 type ArmorClass struct {
 	Type ArmorType // the type of armor
 	DefaultAttribute
+	DefaultCurse
 	DefaultItem
 	NoStats
 }
@@ -71,21 +84,25 @@ func (a *ArmorClass) Rune() rune {
 	return invisibleRune
 }
 
+// Category implements the io.Categorized interface
+func (a *ArmorClass) Category() io.Category { return io.Armor }
+
 // Log implements the Loggable interface
-func (a *ArmorClass) Log() string {
-	return "You have found a " + a.String()
+func (a *ArmorClass) Log(k *Knowledge) string {
+	return "You have found a " + a.String(k)
 }
 
 // String implements the Item interface
-func (a *ArmorClass) String() string {
-	if a.Attr() < 0 {
-		return armorName[a.Type] + " " + strconv.Itoa(a.Attr())
-	} else if a.Attr() > 0 {
-		return armorName[a.Type] + " +" + strconv.Itoa(a.Attr())
-	}
-	return armorName[a.Type]
+func (a *ArmorClass) String(k *Knowledge) string {
+	return armorName[a.Type] + bonusString(a, k) + curseString(a, k)
 }
 
+// Name returns the name of the type of armor
+func (a *ArmorClass) Name() string { return armorName[a.Type] }
+
+// Weight implements the Item interface
+func (a *ArmorClass) Weight() int { return armorWeight[a.Type] }
+
 // Wear implements the Armor interface
 func (a *ArmorClass) Wear(c *stats.Stats) {
 	c.Ac += (armorBase[a.Type] + a.Attr())
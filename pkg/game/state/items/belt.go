@@ -10,24 +10,25 @@ import (
 const beltRune = '{'
 
 const (
-	beltBase = 7
+	beltBase   = 7
+	beltWeight = 4
 )
 
 // Belt is a belt of striking
 type Belt struct {
 	DefaultAttribute
+	DefaultCurse
 	DefaultItem
 }
 
 // String implements the Item interface
-func (b *Belt) String() string {
-	if b.Attr() == 0 {
-		return "a belt of striking"
+func (b *Belt) String(k *Knowledge) string {
+	if b.Attr() == 0 || !k.KnownBonus(b) {
+		return "a belt of striking" + curseString(b, k)
 	} else if b.Attr() > 0 {
-		return fmt.Sprintf("a belt of striking + %v", b.Attr())
-	} else {
-		return fmt.Sprintf("a belt of striking - %v", int(math.Abs(float64(b.Attr()))))
+		return fmt.Sprintf("a belt of striking + %v", b.Attr()) + curseString(b, k)
 	}
+	return fmt.Sprintf("a belt of striking - %v", int(math.Abs(float64(b.Attr())))) + curseString(b, k)
 }
 
 // Rune implements the io.Runeable interface
@@ -39,10 +40,13 @@ func (b *Belt) Rune() rune {
 }
 
 // Log implements the Loggable interface
-func (b *Belt) Log() string {
-	return b.String()
+func (b *Belt) Log(k *Knowledge) string {
+	return b.String(k)
 }
 
+// Weight implements the Item interface
+func (b *Belt) Weight() int { return beltWeight }
+
 // PickUp implements the Item interface
 func (b *Belt) PickUp(s *stats.Stats) {
 	s.Wc += (2 + (b.Attr() << 1))
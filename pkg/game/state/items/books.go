@@ -5,6 +5,7 @@ import (
 	"math/rand"
 
 	"github.com/thorfour/larn/pkg/game/state/stats"
+	"github.com/thorfour/larn/pkg/io"
 )
 
 const (
@@ -26,13 +27,19 @@ func (b *Book) Rune() rune {
 	return invisibleRune
 }
 
+// Category implements the io.Categorized interface
+func (b *Book) Category() io.Category { return io.Book }
+
 // Log implements the Loggable interface
-func (b *Book) Log() string {
+func (b *Book) Log(_ *Knowledge) string {
 	return "You have found a book"
 }
 
 // String returns the texutal representation of the item
-func (b *Book) String() string { return "a book" }
+func (b *Book) String(_ *Knowledge) string { return "a book" }
+
+// Weight implements the Item interface
+func (b *Book) Weight() int { return 1 }
 
 // Read implements the Readable interface
 func (b *Book) Read(s *stats.Stats) []string {
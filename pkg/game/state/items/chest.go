@@ -18,10 +18,13 @@ func (c *Chest) Rune() rune {
 	}
 }
 
-func (c *Chest) Log() string {
+func (c *Chest) Log(_ *Knowledge) string {
 	return "There is a chest here"
 }
 
-func (c *Chest) String() string {
+func (c *Chest) String(_ *Knowledge) string {
 	return "a chest"
 }
+
+// Weight implements the Item interface. Deeper chests hold more
+func (c *Chest) Weight() int { return 30 + int(c.Level) }
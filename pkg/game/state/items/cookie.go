@@ -1,5 +1,12 @@
 package items
 
+import (
+	"math/rand"
+
+	"github.com/thorfour/larn/pkg/game/state/stats"
+	"github.com/thorfour/larn/pkg/io"
+)
+
 const (
 	cookieRune = 'c'
 )
@@ -18,12 +25,33 @@ func (c *Cookie) Rune() rune {
 	return invisibleRune
 }
 
+// Category implements the io.Categorized interface
+func (c *Cookie) Category() io.Category { return io.Food }
+
 // Log implements the displaceable interface
-func (c *Cookie) Log() string {
+func (c *Cookie) Log(_ *Knowledge) string {
 	return "You have found a fortune cookie"
 }
 
 // String implementes the item interface
-func (c *Cookie) String() string {
+func (c *Cookie) String(_ *Knowledge) string {
 	return "a fortune cookie"
 }
+
+// Weight implements the Item interface
+func (c *Cookie) Weight() int { return 1 }
+
+// Eat implements the Food interface. The cookie contains a random fortune
+func (c *Cookie) Eat(_ *stats.Stats) []string {
+	return []string{
+		"The cookie was delicious.",
+		"Inside you find a scrap of paper that says:",
+		fortunes[rand.Intn(len(fortunes))],
+	}
+}
+
+// Stacks implements the Stackable interface
+func (c *Cookie) Stacks(i Item) bool {
+	_, ok := i.(*Cookie)
+	return ok
+}
@@ -1,5 +1,7 @@
 package items
 
+import "github.com/thorfour/larn/pkg/io"
+
 const (
 	doorClosedRune = 'D'
 	doorOpenRune   = 'O'
@@ -22,6 +24,9 @@ func (d *Door) Rune() rune {
 	return invisibleRune
 }
 
+// Category implements the io.Categorized interface
+func (d *Door) Category() io.Category { return io.Door }
+
 // Log implementes the Loggable interface
 func (d *Door) Log() string {
 	if d.Open {
@@ -0,0 +1,25 @@
+package items
+
+// fortunes are the messages found inside fortune cookies
+var fortunes = []string{
+	"gem value = gem * 2 ^ perfection",
+	"sitting down can have unexpected results",
+	"don't pry into the affairs of others",
+	"drinking can be hazardous to your health",
+	"beware of the gusher!",
+	"some monsters are greedy",
+	"nymphs have light fingers",
+	"try kissing a disenchantress!",
+	"hammers and brains don't mix",
+	"what does a potion of cure dianthroritis taste like?",
+	"hit a rust monster and watch your weapon suffer",
+	"cursed armor is hard to take off",
+	"the gods listen to those who pray at their altars",
+	"too much enchantment is a dangerous thing",
+	"money in the bank earns interest",
+	"an education at the college is never wasted",
+	"the LRS always collects its taxes",
+	"a heavy pack slows you down",
+	"identify before you sell",
+	"the eye of larn is worth a fortune",
+}
@@ -1,5 +1,7 @@
 package items
 
+import "github.com/thorfour/larn/pkg/io"
+
 const (
 	fountainRune = 'F'
 )
@@ -17,6 +19,9 @@ func (f *Fountain) Rune() rune {
 	}
 }
 
+// Category implements the io.Categorized interface
+func (f *Fountain) Category() io.Category { return io.Feature }
+
 // Log implements the Displaceable interface
 func (f *Fountain) Log() string {
 	return "There is a Fountain here"
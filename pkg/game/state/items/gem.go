@@ -1,9 +1,17 @@
 package items
 
-import "math/rand"
+import (
+	"math/rand"
+
+	"github.com/thorfour/larn/pkg/io"
+)
 
 const (
 	gemRune = '*'
+
+	// MaxQuality is the highest quality a gem can have
+	MaxQuality = 255
+	gemPrice   = 100 // appraised value of each point of quality
 )
 
 const (
@@ -24,8 +32,8 @@ type Gemstone interface {
 
 // Gem represents a gemstone
 type Gem struct {
-	Stone int // indicates the type of gemstone
-	Value int // the value of the gemstone
+	Stone   int // indicates the type of gemstone
+	Quality int // the quality of the gemstone, which determines its value
 	DefaultItem
 	NoStats
 }
@@ -43,13 +51,16 @@ func (g *Gem) Rune() rune {
 	return invisibleRune
 }
 
+// Category implements the io.Categorized interface
+func (g *Gem) Category() io.Category { return io.Gem }
+
 // Log is the log message for when a user walks over a gemstone
-func (g *Gem) Log() string {
-	return "You have found " + g.String()
+func (g *Gem) Log(k *Knowledge) string {
+	return "You have found " + g.String(k)
 }
 
 // String is the printable description of the gemstone
-func (g *Gem) String() string {
+func (g *Gem) String(_ *Knowledge) string {
 	switch g.Stone {
 	case Diamond:
 		return "a brilliant diamond"
@@ -64,17 +75,41 @@ func (g *Gem) String() string {
 	}
 }
 
-// CreateGem returns a new gemstone
+// Weight implements the Item interface
+func (g *Gem) Weight() int { return 1 }
+
+// Appraisal returns the value of the gemstone
+func (g *Gem) Appraisal() int {
+	return g.Quality * gemPrice
+}
+
+// Perfect doubles the quality of the gemstone, up to MaxQuality
+func (g *Gem) Perfect() {
+	g.Quality *= 2
+	if g.Quality > MaxQuality {
+		g.Quality = MaxQuality
+	}
+}
+
+// gemQuality is the base quality of each type of stone
+var gemQuality = []int{
+	Diamond:  50,
+	Ruby:     40,
+	Emerald:  30,
+	Sapphire: 20,
+}
+
+// CreateGem returns a new gemstone of a random type
 func CreateGem() *Gem {
-	// TODO the value is currently the quality, but sale value shoudl be calculated instead
-	switch rand.Intn(3) {
-	case 0:
-		return &Gem{Stone: Diamond, Value: (rand.Intn(50) + 51) / 10}
-	case 1:
-		return &Gem{Stone: Ruby, Value: (rand.Intn(40) + 41) / 10}
-	case 2:
-		return &Gem{Stone: Ruby, Value: (rand.Intn(30) + 31) / 10}
-	default:
-		return &Gem{Stone: Ruby, Value: (rand.Intn(20) + 21) / 10}
+	return CreateGemOf(rand.Intn(len(gemQuality)), 0)
+}
+
+// CreateGemOf returns a new gemstone of the given type, deeper levels hold finer stones
+func CreateGemOf(stone int, lvl uint) *Gem {
+	q := gemQuality[stone] * (10 + int(lvl)) / 10
+	g := &Gem{Stone: stone, Quality: rand.Intn(q) + 1 + q/10}
+	if g.Quality > MaxQuality {
+		g.Quality = MaxQuality
 	}
+	return g
 }
@@ -0,0 +1,56 @@
+package items
+
+import "testing"
+
+// TestCreateGem ensures every type of stone can be created
+func TestCreateGem(t *testing.T) {
+	seen := make(map[int]bool)
+	for i := 0; i < 1000; i++ {
+		g := CreateGem()
+		if g.Quality <= 0 {
+			t.Errorf("created a worthless gem %v", g.Quality)
+		}
+		seen[g.Stone] = true
+	}
+
+	if len(seen) != len(gemQuality) {
+		t.Errorf("only created %v types of stone", len(seen))
+	}
+}
+
+// TestCreateGemOf ensures deeper levels hold finer stones without exceeding the max quality
+func TestCreateGemOf(t *testing.T) {
+	best := func(lvl uint) int {
+		q := 0
+		for i := 0; i < 1000; i++ {
+			if g := CreateGemOf(Diamond, lvl); g.Quality > q {
+				q = g.Quality
+			}
+		}
+		return q
+	}
+
+	if best(10) <= best(0) {
+		t.Error("deeper diamonds weren't finer")
+	}
+	if best(100) > MaxQuality {
+		t.Error("gem quality exceeded the max")
+	}
+}
+
+// TestPerfect ensures gem perfection doubles quality up to the max
+func TestPerfect(t *testing.T) {
+	g := &Gem{Stone: Ruby, Quality: 40}
+	v := g.Appraisal()
+
+	g.Perfect()
+	if g.Quality != 80 || g.Appraisal() != 2*v {
+		t.Errorf("unexpected quality %v", g.Quality)
+	}
+
+	g.Perfect()
+	g.Perfect()
+	if g.Quality != MaxQuality {
+		t.Errorf("quality %v exceeded the max", g.Quality)
+	}
+}
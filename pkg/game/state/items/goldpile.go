@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/thorfour/larn/pkg/game/state/stats"
+	"github.com/thorfour/larn/pkg/io"
 )
 
 const (
@@ -32,8 +33,11 @@ func (g *GoldPile) Rune() rune {
 	return invisibleRune
 }
 
+// Category implements the io.Categorized interface
+func (g *GoldPile) Category() io.Category { return io.Gold }
+
 // Log implements the Disaplceable interface
-func (g *GoldPile) Log() string {
+func (g *GoldPile) Log(_ *Knowledge) string {
 	return fmt.Sprintf("You have found some gold worth %v", g.Amount)
 }
 
@@ -45,5 +49,8 @@ func (g *GoldPile) PickUp(s *stats.Stats) {
 // Drop implements the item interface
 func (g *GoldPile) Drop(s *stats.Stats) {}
 
+// Weight implements the item interface. A thousand gold pieces weigh a pound
+func (g *GoldPile) Weight() int { return g.Amount / 1000 }
+
 // String implementes the item interface
-func (g *GoldPile) String() string { return "" }
+func (g *GoldPile) String(_ *Knowledge) string { return "" }
@@ -2,6 +2,7 @@ package items
 
 import (
 	"math/rand"
+	"strconv"
 
 	termbox "github.com/nsf/termbox-go"
 	"github.com/thorfour/larn/pkg/game/state/conditions"
@@ -17,14 +18,16 @@ const (
 type Item interface {
 	PickUp(s *stats.Stats)
 	Drop(s *stats.Stats)
-	String() string
+	String(k *Knowledge) string
+	Log(k *Knowledge) string
+	Weight() int
 	io.Runeable
 }
 
-// Food for edible Items (fortune s *ookies)
+// Food for edible Items (fortune cookies)
 type Food interface {
 	Item
-	Eat(s *stats.Stats)
+	Eat(s *stats.Stats) []string
 }
 
 // Quaffable for anything that s *an be quaffed
@@ -58,11 +61,13 @@ type Attributable interface {
 	Attr() int
 	IncrAttr(int)
 	DecrAttr(int)
+	serial() *int
 }
 
 // DefaultAttribute implementes the Attributable interface
 type DefaultAttribute struct {
 	Attribute int
+	Serial    int // assigned by the Knowledge store the first time the attribute is identified
 }
 
 // Attr implements the Attributable interface
@@ -85,16 +90,71 @@ func (d *DefaultAttribute) ResetAttr(i int) {
 	d.Attribute = i
 }
 
-// DefaultItem provide default Fg and Bg functions
+// serial implements the Attributable interface
+func (d *DefaultAttribute) serial() *int {
+	return &d.Serial
+}
+
+// Stackable items that are identical can share an inventory slot
+type Stackable interface {
+	Item
+	Stacks(Item) bool // returns true if the item is identical
+}
+
+// Cursable means an item may be cursed, cursed items refuse to be removed once equipped
+type Cursable interface {
+	IsCursed() bool
+	Curse()
+	Uncurse()
+}
+
+// DefaultCurse implements the Cursable interface
+type DefaultCurse struct {
+	Cursed bool
+}
+
+// IsCursed implements the Cursable interface
+func (d *DefaultCurse) IsCursed() bool { return d.Cursed }
+
+// Curse implements the Cursable interface
+func (d *DefaultCurse) Curse() { d.Cursed = true }
+
+// Uncurse implements the Cursable interface
+func (d *DefaultCurse) Uncurse() { d.Cursed = false }
+
+// bonusString returns the displayable +/- attribute of an item, if it has been identified
+func bonusString(a Attributable, k *Knowledge) string {
+	switch {
+	case !k.KnownBonus(a) || a.Attr() == 0:
+		return ""
+	case a.Attr() < 0:
+		return " " + strconv.Itoa(a.Attr())
+	default:
+		return " +" + strconv.Itoa(a.Attr())
+	}
+}
+
+// curseString returns the displayable curse of an item, if the player knows about it
+func curseString(a Attributable, k *Knowledge) string {
+	if c, ok := a.(Cursable); ok && c.IsCursed() && k.KnownCurse(a) {
+		return " (cursed)"
+	}
+	return ""
+}
+
+// DefaultItem provide default Fg, Bg and Category functions
 type DefaultItem struct {
 	Visibility bool
 }
 
 // Fg for implementing the io.Runeable interface
-func (d *DefaultItem) Fg() termbox.Attribute { return termbox.ColorDefault | termbox.AttrBold }
+func (d *DefaultItem) Fg() termbox.Attribute { return termbox.ColorDefault }
 
 // Bg for implementing the io.Runeable interface
-func (d *DefaultItem) Bg() termbox.Attribute { return termbox.ColorDefault | termbox.AttrBold }
+func (d *DefaultItem) Bg() termbox.Attribute { return termbox.ColorDefault }
+
+// Category implements the io.Categorized interface
+func (d *DefaultItem) Category() io.Category { return io.Item }
 
 // Visible implements the visibility interface
 func (d *DefaultItem) Visible(v bool) { d.Visibility = v }
@@ -196,5 +256,13 @@ func CreateItems(l int) []Item {
 
 		}
 	}
+
+	// Some of the equipment will be cursed
+	for _, i := range created {
+		if c, ok := i.(Cursable); ok && rand.Intn(8) == 0 {
+			c.Curse()
+			i.(Attributable).ResetAttr(-(rand.Intn(3) + 1))
+		}
+	}
 	return created
 }
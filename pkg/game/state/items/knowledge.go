@@ -0,0 +1,163 @@
+package items
+
+// Knowledge is the per-game record of everything the player has identified.
+// A nil Knowledge knows everything, which is used for displaying items in the shops
+type Knowledge struct {
+	Potions map[PotionID]bool // potions the player has learned
+	Scrolls map[ScrollID]bool // scrolls the player has learned
+	Rings   map[RingType]bool // ring types the player has learned
+	Bonus   map[int]bool      // serials of the items whose bonus has been identified
+	Curses  map[int]bool      // serials of the items whose curse has been discovered
+	Serial  int               // last serial handed out to an item
+}
+
+// NewKnowledge returns an empty knowledge store
+func NewKnowledge() *Knowledge {
+	return &Knowledge{
+		Potions: make(map[PotionID]bool),
+		Scrolls: make(map[ScrollID]bool),
+		Rings:   make(map[RingType]bool),
+		Bonus:   make(map[int]bool),
+		Curses:  make(map[int]bool),
+	}
+}
+
+// KnownPotion returns true if the player knows the potion
+func (k *Knowledge) KnownPotion(p PotionID) bool {
+	return k == nil || k.Potions[p]
+}
+
+// LearnPotion adds a potion to the list of known potions
+func (k *Knowledge) LearnPotion(p PotionID) {
+	k.Potions[p] = true
+}
+
+// ForgetPotion removes a potion from the list of known potions
+func (k *Knowledge) ForgetPotion(p PotionID) {
+	delete(k.Potions, p)
+}
+
+// DiscoveredPotions returns the names of the known potions in potion order
+func (k *Knowledge) DiscoveredPotions() []string {
+	var names []string
+	for id := range potionname {
+		if k.KnownPotion(PotionID(id)) {
+			names = append(names, potionname[id])
+		}
+	}
+	return names
+}
+
+// KnownScroll returns true if the player knows the scroll
+func (k *Knowledge) KnownScroll(id ScrollID) bool {
+	return k == nil || k.Scrolls[id]
+}
+
+// LearnScroll marks a scroll has having been learned (via reading or identify)
+func (k *Knowledge) LearnScroll(id ScrollID) {
+	k.Scrolls[id] = true
+}
+
+// ForgetScroll forget having learned a scroll
+func (k *Knowledge) ForgetScroll(id ScrollID) {
+	delete(k.Scrolls, id)
+}
+
+// DiscoveredScrolls returns the names of the known scrolls in scroll order
+func (k *Knowledge) DiscoveredScrolls() []string {
+	var names []string
+	for id := range scrollname {
+		if k.KnownScroll(ScrollID(id)) {
+			names = append(names, scrollname[id])
+		}
+	}
+	return names
+}
+
+// KnownRing returns true if the player knows the ring type
+func (k *Knowledge) KnownRing(t RingType) bool {
+	return k == nil || k.Rings[t]
+}
+
+// LearnRing marks a ring type as having been learned
+func (k *Knowledge) LearnRing(t RingType) {
+	k.Rings[t] = true
+}
+
+// KnownBonus returns true if the +/- attribute of the item has been identified
+func (k *Knowledge) KnownBonus(a Attributable) bool {
+	if k == nil {
+		return true
+	}
+	s := a.serial()
+	return *s != 0 && k.Bonus[*s]
+}
+
+// LearnBonus identifies the +/- attribute of the item
+func (k *Knowledge) LearnBonus(a Attributable) {
+	s := a.serial()
+	if *s == 0 { // first time this item has been identified, give it a serial
+		k.Serial++
+		*s = k.Serial
+	}
+	k.Bonus[*s] = true
+}
+
+// KnownCurse returns true if the player knows whether the item is cursed
+func (k *Knowledge) KnownCurse(a Attributable) bool {
+	if k.KnownBonus(a) {
+		return true
+	}
+	s := a.serial()
+	return *s != 0 && k.Curses[*s]
+}
+
+// LearnCurse reveals the curse on an item without identifying its bonus
+func (k *Knowledge) LearnCurse(a Attributable) {
+	s := a.serial()
+	if *s == 0 {
+		k.Serial++
+		*s = k.Serial
+	}
+	k.Curses[*s] = true
+}
+
+// Identify learns everything there is to know about the item
+func (k *Knowledge) Identify(i Item) {
+	switch t := i.(type) {
+	case *Potion:
+		k.LearnPotion(t.ID)
+	case *Scroll:
+		k.LearnScroll(t.ID)
+	case *Ring:
+		k.LearnRing(t.Type)
+	}
+
+	if a, ok := i.(Attributable); ok {
+		k.LearnBonus(a)
+	}
+}
+
+// Identified returns true if there is nothing left to learn about the item
+func (k *Knowledge) Identified(i Item) bool {
+	switch t := i.(type) {
+	case *Potion:
+		return k.KnownPotion(t.ID)
+	case *Scroll:
+		return k.KnownScroll(t.ID)
+	case *Ring:
+		return k.KnownRing(t.Type) && k.KnownBonus(t)
+	case *Special: // special items are one of a kind, there's nothing to identify
+		return true
+	case Attributable:
+		return k.KnownBonus(t)
+	}
+
+	return true
+}
+
+// Forget forgets all the potions and scrolls the player has learned
+func (k *Knowledge) Forget() {
+	k.Potions = make(map[PotionID]bool)
+	k.Scrolls = make(map[ScrollID]bool)
+}
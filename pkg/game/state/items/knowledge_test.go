@@ -0,0 +1,104 @@
+package items
+
+import "testing"
+
+// TestKnowledgePerGame ensures learning an item in one game doesn't leak into another
+func TestKnowledgePerGame(t *testing.T) {
+	k0 := NewKnowledge()
+	k1 := NewKnowledge()
+
+	p := &Potion{ID: Healing}
+	k0.Identify(p)
+
+	if p.String(k0) != "a potion of healing" {
+		t.Errorf("unexpected potion name %q", p.String(k0))
+	}
+
+	if p.String(k1) != "a potion" {
+		t.Errorf("potion knowledge leaked between games: %q", p.String(k1))
+	}
+}
+
+// TestKnowledgeBonus ensures weapon bonuses are hidden until identified
+func TestKnowledgeBonus(t *testing.T) {
+	k := NewKnowledge()
+
+	w := &WeaponClass{Type: Dagger}
+	w.ResetAttr(2)
+	other := &WeaponClass{Type: Dagger}
+	other.ResetAttr(3)
+
+	if w.String(k) != "dagger" {
+		t.Errorf("unidentified bonus was displayed: %q", w.String(k))
+	}
+
+	k.Identify(w)
+	if w.String(k) != "dagger +2" {
+		t.Errorf("identified bonus wasn't displayed: %q", w.String(k))
+	}
+
+	if other.String(k) != "dagger" {
+		t.Errorf("identifying one weapon identified another: %q", other.String(k))
+	}
+
+	if w.String(nil) != "dagger +2" || other.String(nil) != "dagger +3" {
+		t.Error("nil knowledge should display everything")
+	}
+}
+
+// TestKnowledgeForget ensures forgetfulness only forgets potions and scrolls
+func TestKnowledgeForget(t *testing.T) {
+	k := NewKnowledge()
+
+	r := &Ring{Type: Energy}
+	s := &Scroll{ID: Identify}
+	k.Identify(r)
+	k.Identify(s)
+	k.Forget()
+
+	if k.Identified(s) {
+		t.Error("scroll wasn't forgotten")
+	}
+
+	if !k.Identified(r) {
+		t.Error("ring was forgotten")
+	}
+}
+
+// TestKnowledgeCurse ensures curses are hidden until discovered
+func TestKnowledgeCurse(t *testing.T) {
+	k := NewKnowledge()
+
+	a := &ArmorClass{Type: Leather}
+	a.Curse()
+
+	if a.String(k) != "leather" {
+		t.Errorf("undiscovered curse was displayed: %q", a.String(k))
+	}
+
+	k.LearnCurse(a)
+	if a.String(k) != "leather (cursed)" {
+		t.Errorf("discovered curse wasn't displayed: %q", a.String(k))
+	}
+}
+
+func TestDiscoveries(t *testing.T) {
+	k := NewKnowledge()
+	k.LearnPotion(Sleep)
+	k.LearnPotion(Water)
+	k.LearnScroll(Identify)
+
+	p := k.DiscoveredPotions()
+	if len(p) != 2 || p[0] != "sleep" || p[1] != "water" {
+		t.Errorf("unexpected potions %v", p)
+	}
+
+	if s := k.DiscoveredScrolls(); len(s) != 1 || s[0] != "identify" {
+		t.Errorf("unexpected scrolls %v", s)
+	}
+
+	spells := KnownSpells(map[string]bool{"mle": true, "pro": true})
+	if len(spells) != 2 || spells[0].Code != "pro" || spells[1].Code != "mle" {
+		t.Errorf("unexpected spells %v", spells)
+	}
+}
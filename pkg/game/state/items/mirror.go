@@ -1,5 +1,7 @@
 package items
 
+import "github.com/thorfour/larn/pkg/io"
+
 const (
 	mirrorRune = 'M'
 )
@@ -17,6 +19,9 @@ func (m *Mirror) Rune() rune {
 	}
 }
 
+// Category implements the io.Categorized interface
+func (m *Mirror) Category() io.Category { return io.Feature }
+
 // Log implementes the Loggable interface
 func (m *Mirror) Log() string {
 	return "There is a mirror here"
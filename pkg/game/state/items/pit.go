@@ -1,5 +1,7 @@
 package items
 
+import "github.com/thorfour/larn/pkg/io"
+
 const (
 	pitRune = 'P'
 )
@@ -17,6 +19,9 @@ func (p *Pit) Rune() rune {
 	}
 }
 
+// Category implements the io.Categorized interface
+func (p *Pit) Category() io.Category { return io.Trap }
+
 // Log implements the Displaceable interface
 func (p *Pit) Log() string {
 	return "You are standing at the top of a pit"
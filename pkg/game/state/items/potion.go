@@ -7,6 +7,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"github.com/thorfour/larn/pkg/game/state/conditions"
 	"github.com/thorfour/larn/pkg/game/state/stats"
+	"github.com/thorfour/larn/pkg/io"
 )
 
 const (
@@ -111,13 +112,6 @@ var potionname = []string{
 	"see invisible",
 }
 
-// knownPotions map of all potions the player has learned
-var knownPotions map[PotionID]bool
-
-func init() {
-	knownPotions = make(map[PotionID]bool)
-}
-
 // Potion that a player may drink for an effect
 type Potion struct {
 	ID    PotionID
@@ -134,36 +128,27 @@ func (p *Potion) Rune() rune {
 	return invisibleRune
 }
 
+// Category implements the io.Categorized interface
+func (p *Potion) Category() io.Category { return io.Potion }
+
 // Log implements the Disaplceable interface
-func (p *Potion) Log() string {
-	if knownPotions[p.ID] {
+func (p *Potion) Log(k *Knowledge) string {
+	if k.KnownPotion(p.ID) {
 		return fmt.Sprintf("You have found a magic potion of %s", potionname[p.ID])
 	}
 	return "You have found a magic potion"
 }
 
 // String implements the Item interface
-func (p *Potion) String() string {
-	if knownPotions[p.ID] || p.Store {
+func (p *Potion) String(k *Knowledge) string {
+	if k.KnownPotion(p.ID) || p.Store {
 		return fmt.Sprintf("a potion of %s", potionname[p.ID])
 	}
 	return "a potion"
 }
 
-// KnownPotion returns true if the player knows the potion (used for tradig post)
-func KnownPotion(p PotionID) bool {
-	return knownPotions[p]
-}
-
-// LearnPotion adds a potion to the list of known potions
-func LearnPotion(p PotionID) {
-	knownPotions[p] = true
-}
-
-// ForgetPotion removes a potion from the list of known potions
-func ForgetPotion(p PotionID) {
-	delete(knownPotions, p)
-}
+// Weight implements the Item interface
+func (p *Potion) Weight() int { return 1 }
 
 // NewPotion randomly returns a new potion
 func NewPotion() *Potion {
@@ -174,7 +159,6 @@ func NewPotion() *Potion {
 
 // Quaff implemtents the Quaffable interface. Applies a potions effects to the given stats. Returns a log of events
 func (p *Potion) Quaff(s *stats.Stats, a *conditions.ActiveConditions) ([]string, PotionID) {
-	LearnPotion(p.ID)
 	var l []string
 	switch p.ID {
 	case Sleep:
@@ -238,10 +222,10 @@ func (p *Potion) Quaff(s *stats.Stats, a *conditions.ActiveConditions) ([]string
 	case Water:
 		l = append(l, "This potion has no taste to it")
 	case Blindness:
-		a.Refresh(conditions.Blindness, 500, nil)
+		a.Refresh(conditions.Blindness, 500, 0)
 		l = append(l, "You can't see anything!")
 	case Confusion:
-		a.Refresh(conditions.Confusion, 21+rand.Intn(9), nil)
+		a.Refresh(conditions.Confusion, 21+rand.Intn(9), 0)
 		l = append(l, "You feel confused")
 	case Heroism:
 		if !a.EffectActive(conditions.Heroic) {
@@ -252,14 +236,7 @@ func (p *Potion) Quaff(s *stats.Stats, a *conditions.ActiveConditions) ([]string
 			s.Str += 11
 			s.Intelligence += 11
 		}
-		a.Refresh(conditions.Heroic, 250, func() {
-			s.Cha -= 11
-			s.Wisdom -= 11
-			s.Con -= 11
-			s.Dex -= 11
-			s.Str -= 11
-			s.Intelligence -= 11
-		})
+		a.Refresh(conditions.Heroic, 250, 11)
 		l = append(l, "WOW!! You feel Super-fantastic!!!")
 	case Sturdiness:
 		s.Con++
@@ -268,12 +245,10 @@ func (p *Potion) Quaff(s *stats.Stats, a *conditions.ActiveConditions) ([]string
 		if !a.EffectActive(conditions.GiantStrength) {
 			s.StrExtra += 21
 		}
-		a.Refresh(conditions.GiantStrength, 700, func() {
-			s.Str -= 20
-		})
+		a.Refresh(conditions.GiantStrength, 700, 21)
 		l = append(l, "You now have incredibly bulgin muscles!!!")
 	case FireResistance:
-		a.Refresh(conditions.FireResistance, 1000, nil)
+		a.Refresh(conditions.FireResistance, 1000, 0)
 		l = append(l, "You feel a chill run up your spine!")
 	case TreasureFinding:
 		l = append(l, "You feel greedy . . .")
@@ -282,10 +257,10 @@ func (p *Potion) Quaff(s *stats.Stats, a *conditions.ActiveConditions) ([]string
 	case CureDianthroritis:
 		l = append(l, "You don't seem to be affected")
 	case Poison:
-		a.Refresh(conditions.HalfDamage, 201+rand.Intn(200), nil)
+		a.Refresh(conditions.HalfDamage, 201+rand.Intn(200), 0)
 		l = append(l, "You feel a sickness engulf you")
 	case SeeInvisible:
-		a.Refresh(conditions.SeeInvisible, rand.Intn(1000)+401, nil)
+		a.Refresh(conditions.SeeInvisible, rand.Intn(1000)+401, 0)
 		l = append(l, "You feel your vision sharpen")
 	default:
 		log.WithField("id", p.ID).Error("unknown potion consumed")
@@ -293,3 +268,9 @@ func (p *Potion) Quaff(s *stats.Stats, a *conditions.ActiveConditions) ([]string
 
 	return l, p.ID
 }
+
+// Stacks implements the Stackable interface
+func (p *Potion) Stacks(i Item) bool {
+	o, ok := i.(*Potion)
+	return ok && o.ID == p.ID
+}
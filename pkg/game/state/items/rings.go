@@ -1,9 +1,8 @@
 package items
 
 import (
-	"strconv"
-
 	"github.com/thorfour/larn/pkg/game/state/stats"
+	"github.com/thorfour/larn/pkg/io"
 )
 
 // RingType is the type of ring
@@ -45,6 +44,7 @@ var ringName = map[RingType]string{
 type Ring struct {
 	Type RingType // the type of ring
 	DefaultAttribute
+	DefaultCurse
 	DefaultItem
 }
 
@@ -56,21 +56,25 @@ func (r *Ring) Rune() rune {
 	return invisibleRune
 }
 
+// Category implements the io.Categorized interface
+func (r *Ring) Category() io.Category { return io.Ring }
+
 // Log implements the Loggable interface
-func (r *Ring) Log() string {
-	return "You have found a " + r.String()
+func (r *Ring) Log(k *Knowledge) string {
+	return "You have found a " + r.String(k)
 }
 
 // String implements the Item interface
-func (r *Ring) String() string {
-	if r.Attr() < 0 {
-		return ringName[r.Type] + " " + strconv.Itoa(r.Attr())
-	} else if r.Attr() > 0 {
-		return ringName[r.Type] + " +" + strconv.Itoa(r.Attr())
+func (r *Ring) String(k *Knowledge) string {
+	if !k.KnownRing(r.Type) {
+		return "ring" + curseString(r, k)
 	}
-	return ringName[r.Type]
+	return ringName[r.Type] + bonusString(r, k) + curseString(r, k)
 }
 
+// Weight implements the Item interface
+func (r *Ring) Weight() int { return 1 }
+
 // FIXME can you wear or wield rings?
 
 // PickUp implements the Item interface
@@ -82,6 +86,12 @@ func (r *Ring) PickUp(s *stats.Stats) {
 		s.Intelligence += uint(1 + r.Attr())
 	case Dexterity:
 		s.Dex += uint(1 + r.Attr())
+	case Regen:
+		s.Regen += 1 + r.Attr()
+	case ExtraRegen:
+		s.Regen += 5 * (1 + r.Attr())
+	case Energy:
+		s.Energy += 1 + r.Attr()
 	}
 }
 
@@ -94,5 +104,11 @@ func (r *Ring) Drop(s *stats.Stats) {
 		s.Intelligence -= uint(1 + r.Attr())
 	case Dexterity:
 		s.Dex -= uint(1 + r.Attr())
+	case Regen:
+		s.Regen -= 1 + r.Attr()
+	case ExtraRegen:
+		s.Regen -= 5 * (1 + r.Attr())
+	case Energy:
+		s.Energy -= 1 + r.Attr()
 	}
 }
@@ -3,6 +3,9 @@ package items
 import (
 	"fmt"
 	"math/rand"
+
+	"github.com/thorfour/larn/pkg/game/state/stats"
+	"github.com/thorfour/larn/pkg/io"
 )
 
 const (
@@ -109,9 +112,6 @@ var scprob = []ScrollID{0, 0, 0, 0, 1, 1, 1, 1, 1, 2, 2, 2, 2, 2, 2, 3, 3,
 	15, 15, 16, 16, 16, 17, 17, 18, 18, 19, 19, 19, 20, 20, 20, 20, 21, 22,
 	22, 22, 23}
 
-// knownScrolls a list of all the scrolls a player has discovered
-var knownScrolls map[ScrollID]bool
-
 // Scroll a player can read
 type Scroll struct {
 	ID    ScrollID
@@ -120,21 +120,6 @@ type Scroll struct {
 	NoStats
 }
 
-// KnownScroll returns true if the scroll is known
-func KnownScroll(id ScrollID) bool {
-	return knownScrolls[id]
-}
-
-// LearnScroll marks a scroll has having been learned (via reading or identify)
-func LearnScroll(id ScrollID) {
-	knownScrolls[id] = true
-}
-
-// ForgetScroll forget having learned a scroll
-func ForgetScroll(id ScrollID) {
-	delete(knownScrolls, id)
-}
-
 // Rune implements the io.Runeable interface
 func (s *Scroll) Rune() rune {
 	if s.Visibility {
@@ -143,22 +128,36 @@ func (s *Scroll) Rune() rune {
 	return invisibleRune
 }
 
+// Category implements the io.Categorized interface
+func (s *Scroll) Category() io.Category { return io.Scroll }
+
 // Log implements the Disaplceable interface
-func (s *Scroll) Log() string {
-	if knownScrolls[s.ID] {
+func (s *Scroll) Log(k *Knowledge) string {
+	if k.KnownScroll(s.ID) {
 		return fmt.Sprintf("You have found a magic scroll of %s", idToName(s.ID))
 	}
 	return "You have found a magic scroll"
 }
 
 // String implements the Item interface
-func (s *Scroll) String() string {
-	if knownScrolls[s.ID] || s.Store {
+func (s *Scroll) String(k *Knowledge) string {
+	if k.KnownScroll(s.ID) || s.Store {
 		return fmt.Sprintf("a scroll of %s", idToName(s.ID))
 	}
 	return "a scroll"
 }
 
+// Weight implements the Item interface
+func (s *Scroll) Weight() int { return 1 }
+
+// Read implements the Readable interface. Effects that reach beyond the reader are applied by the game state
+func (s *Scroll) Read(_ *stats.Stats) []string {
+	if s.ID == Paper {
+		return []string{"This scroll appears to be blank"}
+	}
+	return nil
+}
+
 // NewScroll returns a random scroll
 func NewScroll() *Scroll {
 	return &Scroll{
@@ -170,3 +169,9 @@ func NewScroll() *Scroll {
 func idToName(id ScrollID) string {
 	return scrollname[id]
 }
+
+// Stacks implements the Stackable interface
+func (s *Scroll) Stacks(i Item) bool {
+	o, ok := i.(*Scroll)
+	return ok && o.ID == s.ID
+}
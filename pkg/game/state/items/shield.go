@@ -1,21 +1,22 @@
 package items
 
 import (
-	"strconv"
-
 	"github.com/thorfour/larn/pkg/game/state/stats"
+	"github.com/thorfour/larn/pkg/io"
 )
 
 const shieldRune = ']'
 
 const (
-	shieldWC = 8
-	shieldAC = 2
+	shieldWC     = 8
+	shieldAC     = 2
+	shieldWeight = 7
 )
 
 // Shield is a shield item
 type Shield struct {
 	DefaultAttribute
+	DefaultCurse
 	DefaultItem
 	NoStats
 }
@@ -28,22 +29,22 @@ func (s *Shield) Rune() rune {
 	return invisibleRune
 }
 
+// Category implements the io.Categorized interface
+func (s *Shield) Category() io.Category { return io.Armor }
+
 // Log implements the Loggable interface
-func (s *Shield) Log() string {
-	return "You have found a " + s.String()
+func (s *Shield) Log(k *Knowledge) string {
+	return "You have found a " + s.String(k)
 }
 
 // String implements the Item interface
-func (s *Shield) String() string {
-	if s.Attr() < 0 {
-		return "shield " + strconv.Itoa(s.Attr())
-	} else if s.Attr() > 0 {
-		return "shield" + " +" + strconv.Itoa(s.Attr())
-	}
-
-	return "shield"
+func (s *Shield) String(k *Knowledge) string {
+	return "shield" + bonusString(s, k) + curseString(s, k)
 }
 
+// Weight implements the Item interface
+func (s *Shield) Weight() int { return shieldWeight }
+
 // Wield implements the Weapon interface
 func (s *Shield) Wield(c *stats.Stats) {
 	c.Wc += (shieldWC + s.Attr())
@@ -36,8 +36,8 @@ type Special struct {
 }
 
 // Log implements the Loggable interface
-func (s *Special) Log() string {
-	return s.String()
+func (s *Special) Log(k *Knowledge) string {
+	return s.String(k)
 }
 
 // Rune implements the io.Runeable interface
@@ -50,10 +50,18 @@ func (s *Special) Rune() rune {
 }
 
 // String implements the Item interface
-func (s *Special) String() string {
+func (s *Special) String(_ *Knowledge) string {
 	return specialString[s.Type]
 }
 
+// Weight implements the Item interface
+func (s *Special) Weight() int {
+	if s.Type == Orb {
+		return 4
+	}
+	return 1
+}
+
 // PickUp implements the Item interface
 func (s *Special) PickUp(t *stats.Stats) {
 	t.Special[int(s.Type)] = true
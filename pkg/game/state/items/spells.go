@@ -2,6 +2,7 @@ package items
 
 import (
 	termbox "github.com/nsf/termbox-go"
+	"github.com/thorfour/larn/pkg/io"
 )
 
 const (
@@ -142,6 +143,31 @@ func SpellFromIndex(i int) Spell { return spellIndex[i] }
 // SpellFromCode returns a spell from the 3 letter code
 func SpellFromCode(c string) Spell { return spellLUT[c] }
 
+// LookupSpell returns the spell for the 3 letter code and whether the code is a real spell
+func LookupSpell(c string) (Spell, bool) {
+	s, ok := spellLUT[c]
+	return s, ok
+}
+
+// Level returns the lowest character level able to cast the spell
+func (s Spell) Level() int {
+	if s.Id < 3 {
+		return 1
+	}
+	return s.Id / 3
+}
+
+// KnownSpells returns the spells whose codes are in known, in spell order
+func KnownSpells(known map[string]bool) []Spell {
+	var spells []Spell
+	for _, s := range spellIndex {
+		if known[s.Code] {
+			spells = append(spells, s)
+		}
+	}
+	return spells
+}
+
 // ProjectileSpell is a type of spell that cast a projectile
 type ProjectileSpell struct {
 	R rune
@@ -152,6 +178,9 @@ func (p *ProjectileSpell) Rune() rune {
 	return p.R
 }
 
+// Category implements the io.Categorized interface
+func (p *ProjectileSpell) Category() io.Category { return io.Spell }
+
 // Fg implements the io.Runeable interface
 func (p *ProjectileSpell) Fg() termbox.Attribute { return termbox.ColorDefault }
 
@@ -1,5 +1,7 @@
 package items
 
+import "github.com/thorfour/larn/pkg/io"
+
 const (
 	statueRune = '&'
 )
@@ -17,6 +19,9 @@ func (s *Statue) Rune() rune {
 	}
 }
 
+// Category implements the io.Categorized interface
+func (s *Statue) Category() io.Category { return io.Feature }
+
 // Log implements the Displaceable interface
 func (s *Statue) Log() string {
 	return "You are standing in front of a statue"
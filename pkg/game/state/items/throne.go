@@ -1,5 +1,7 @@
 package items
 
+import "github.com/thorfour/larn/pkg/io"
+
 const (
 	throneRune     = 'T'
 	deadThroneRune = 't'
@@ -18,6 +20,9 @@ func (t *Throne) Rune() rune {
 	}
 }
 
+// Category implements the io.Categorized interface
+func (t *Throne) Category() io.Category { return io.Feature }
+
 // Log implements the Displaceable interface
 func (t *Throne) Log() string {
 	return "There is a handsome jewel encrusted throne"
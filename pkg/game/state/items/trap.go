@@ -1,5 +1,7 @@
 package items
 
+import "github.com/thorfour/larn/pkg/io"
+
 const (
 	TeleTrap = iota
 	ArrowTrap
@@ -14,9 +16,27 @@ const (
 
 type Trap struct {
 	TrapType int
+	Found    bool // the player has discovered the trap, hidden traps stay invisible
 	DefaultItem
 }
 
+// Visible implements the Visibility interface. Traps can only be seen once they've been found
+func (t *Trap) Visible(v bool) { t.Visibility = v && t.Found }
+
+// Name returns the kind of trap
+func (t *Trap) Name() string {
+	switch t.TrapType {
+	case TeleTrap:
+		return "teleport trap"
+	case ArrowTrap:
+		return "arrow trap"
+	case DartTrap:
+		return "dart trap"
+	default:
+		return "trapdoor"
+	}
+}
+
 // Rune implements the io.Runeable interface
 func (t *Trap) Rune() rune {
 	if !t.Visibility {
@@ -31,6 +51,9 @@ func (t *Trap) Rune() rune {
 	}
 }
 
+// Category implements the io.Categorized interface
+func (t *Trap) Category() io.Category { return io.Trap }
+
 func (t *Trap) Log() string {
 	switch t.TrapType {
 	case TeleTrap:
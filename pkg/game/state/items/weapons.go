@@ -2,9 +2,9 @@ package items
 
 import (
 	"math/rand"
-	"strconv"
 
 	"github.com/thorfour/larn/pkg/game/state/stats"
+	"github.com/thorfour/larn/pkg/io"
 )
 
 // WeaponType indicate the type of weapon
@@ -49,6 +49,20 @@ var weaponBase = map[WeaponType]int{
 	LanceOfDeath:    19,
 }
 
+// Map of all the weapon weights
+var weaponWeight = map[WeaponType]int{
+	Dagger:          1,
+	Spear:           8,
+	Flail:           20,
+	BattleAxe:       23,
+	LongSword:       20,
+	TwoHandedSword:  23,
+	SunSword:        20,
+	SwordOfSlashing: 23,
+	BessmansHammer:  30,
+	LanceOfDeath:    15,
+}
+
 // Map of all the displayable weapon names
 var weaponName = map[WeaponType]string{
 	SunSword:        "sun sword",
@@ -67,6 +81,7 @@ var weaponName = map[WeaponType]string{
 type WeaponClass struct {
 	Type WeaponType // the type of weapon
 	DefaultAttribute
+	DefaultCurse
 	DefaultItem
 	NoStats
 }
@@ -79,21 +94,25 @@ func (a *WeaponClass) Rune() rune {
 	return invisibleRune
 }
 
+// Category implements the io.Categorized interface
+func (a *WeaponClass) Category() io.Category { return io.Weapon }
+
 // Log implements the Loggable interface
-func (a *WeaponClass) Log() string {
-	return "You have found a " + a.String()
+func (a *WeaponClass) Log(k *Knowledge) string {
+	return "You have found a " + a.String(k)
 }
 
 // String implements the Item interface
-func (a *WeaponClass) String() string {
-	if a.Attr() < 0 {
-		return weaponName[a.Type] + " " + strconv.Itoa(a.Attr())
-	} else if a.Attr() > 0 {
-		return weaponName[a.Type] + " +" + strconv.Itoa(a.Attr())
-	}
-	return weaponName[a.Type]
+func (a *WeaponClass) String(k *Knowledge) string {
+	return weaponName[a.Type] + bonusString(a, k) + curseString(a, k)
 }
 
+// Name returns the name of the type of weapon
+func (a *WeaponClass) Name() string { return weaponName[a.Type] }
+
+// Weight implements the Item interface
+func (a *WeaponClass) Weight() int { return weaponWeight[a.Type] }
+
 // Wield implements the Weapon interface
 func (a *WeaponClass) Wield(c *stats.Stats) {
 	switch a.Type { // Special weapon handling
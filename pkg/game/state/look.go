@@ -0,0 +1,56 @@
+package state
+
+import (
+	"fmt"
+
+	"github.com/thorfour/larn/pkg/game/state/character"
+	"github.com/thorfour/larn/pkg/game/state/items"
+	"github.com/thorfour/larn/pkg/game/state/maps"
+	"github.com/thorfour/larn/pkg/game/state/monster"
+	"github.com/thorfour/larn/pkg/game/state/types"
+)
+
+// Describe returns what the player can see at the coordinate. Only what the player can see is described,
+// so blindness hides monsters and invisible stalkers need see invisible
+func (s *State) Describe(c types.Coordinate) string {
+	if !s.maps.ValidCoordinate(c) {
+		return "You can't see anything there"
+	}
+
+	obj := s.CurrentMap()[c.Y][c.X]
+	if obj.Rune() == ' ' {
+		return "You can't see anything there"
+	}
+
+	switch t := obj.(type) {
+	case *character.Character:
+		return "That's you"
+	case monster.Revealed:
+		return describeMonster(t.Monster)
+	case *monster.Monster:
+		return describeMonster(t)
+	case *maps.Stairs:
+		return fmt.Sprintf("You see a %s", t.Name())
+	case *maps.Wall:
+		return "You see a wall"
+	case maps.Empty:
+		return "You see the floor"
+	case *items.Trap:
+		return fmt.Sprintf("You see a %s", t.Name())
+	case *items.GoldPile:
+		return "You see some gold"
+	case items.Item:
+		return fmt.Sprintf("You see: %s", t.String(s.Known))
+	case maps.Entrance:
+		return fmt.Sprintf("You see the %s", t.Name())
+	case maps.Loggable:
+		return t.Log()
+	default:
+		return fmt.Sprintf("You see a %c", obj.Rune())
+	}
+}
+
+// describeMonster returns the monster's name and how hurt it is
+func describeMonster(m *monster.Monster) string {
+	return fmt.Sprintf("You see a %s (%s)", m.Name(), m.Health())
+}
@@ -0,0 +1,69 @@
+package state
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/thorfour/larn/pkg/game/state/conditions"
+	"github.com/thorfour/larn/pkg/game/state/monster"
+	"github.com/thorfour/larn/pkg/game/state/types"
+)
+
+// TestDescribe ensures looking only describes what the player can see
+func TestDescribe(t *testing.T) {
+	s := New(0)
+	rand.Seed(1)
+
+	if d := s.Describe(types.Coordinate(s.C.Location())); d != "That's you" {
+		t.Errorf("unexpected description of the player %q", d)
+	}
+
+	if d := s.Describe(types.Coordinate{X: -1, Y: -1}); d != "You can't see anything there" {
+		t.Errorf("unexpected description off the map %q", d)
+	}
+
+	c := s.maps.AdjacentCoords(s.C.Location())[0]
+	mon := monster.New(monster.Jackal)
+	mon.Visible(true)
+	mon.Displaced = s.maps.Swap(c, mon)
+
+	if d := s.Describe(c); d != "You see a jackal (unhurt)" {
+		t.Errorf("unexpected description of the monster %q", d)
+	}
+
+	mon.Info.Hitpoints = 0
+	if d := s.Describe(c); d != "You see a jackal (almost dead)" {
+		t.Errorf("unexpected description of the hurt monster %q", d)
+	}
+
+	s.C.Cond.Add(conditions.Blindness, 100, 0)
+	if d := s.Describe(c); d == "You see a jackal (almost dead)" {
+		t.Error("blind player saw the monster")
+	}
+}
+
+// TestBestiary ensures monsters are only recorded once the player has seen them
+func TestBestiary(t *testing.T) {
+	s := New(0)
+	rand.Seed(1)
+
+	c := s.maps.AdjacentCoords(s.C.Location())[0]
+	mon := monster.New(monster.Jackal)
+	mon.Displaced = s.maps.Swap(c, mon)
+
+	s.C.Cond.Add(conditions.Blindness, 100, 0)
+	s.look()
+	if s.Seen.Encountered[monster.Jackal] {
+		t.Error("blind player encountered a monster")
+	}
+
+	s.C.Cond.Remove(conditions.Blindness)
+	s.look()
+	if !s.Seen.Encountered[monster.Jackal] {
+		t.Fatal("visible monster wasn't encountered")
+	}
+
+	if len(s.Bestiary()) != 2 {
+		t.Errorf("unexpected bestiary %v", s.Bestiary())
+	}
+}
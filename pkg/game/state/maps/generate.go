@@ -59,7 +59,7 @@ func newLevel(lvl uint) [][]io.Runeable {
 	// Carve out the passageways
 	if lvl != homeLevel {
 		//carve(level)
-		eat(types.Coordinate{1, 1}, level) // original larn
+		eat(types.Coordinate{X: 1, Y: 1}, level) // original larn
 	}
 
 	return level
@@ -71,34 +71,34 @@ func eat(c types.Coordinate, lvl [][]io.Runeable) {
 	for try := 2; try > 0; { // try all directions twice
 		switch dir {
 		case 1: // West
-			if c.X <= 2 || !isWall(types.Coordinate{c.X - 1, c.Y}, lvl) || !isWall(types.Coordinate{c.X - 2, c.Y}, lvl) { // Only eat if at least the next 2 are walls
+			if c.X <= 2 || !isWall(types.Coordinate{X: c.X - 1, Y: c.Y}, lvl) || !isWall(types.Coordinate{X: c.X - 2, Y: c.Y}, lvl) { // Only eat if at least the next 2 are walls
 				break
 			}
 			lvl[c.Y][c.X-1] = Empty{}
 			lvl[c.Y][c.X-2] = Empty{}
-			eat(types.Coordinate{c.X - 2, c.Y}, lvl)
+			eat(types.Coordinate{X: c.X - 2, Y: c.Y}, lvl)
 		case 2: // East
-			if c.X >= width-3 || !isWall(types.Coordinate{c.X + 1, c.Y}, lvl) || !isWall(types.Coordinate{c.X + 2, c.Y}, lvl) { // Only eat if at least the next 2 are walls
+			if c.X >= width-3 || !isWall(types.Coordinate{X: c.X + 1, Y: c.Y}, lvl) || !isWall(types.Coordinate{X: c.X + 2, Y: c.Y}, lvl) { // Only eat if at least the next 2 are walls
 				break
 			}
 
 			lvl[c.Y][c.X+1] = Empty{}
 			lvl[c.Y][c.X+2] = Empty{}
-			eat(types.Coordinate{c.X + 2, c.Y}, lvl)
+			eat(types.Coordinate{X: c.X + 2, Y: c.Y}, lvl)
 		case 3: // South
-			if c.Y <= 2 || !isWall(types.Coordinate{c.X, c.Y - 1}, lvl) || !isWall(types.Coordinate{c.X, c.Y - 2}, lvl) { // Only eat if at least the next 2 are walls
+			if c.Y <= 2 || !isWall(types.Coordinate{X: c.X, Y: c.Y - 1}, lvl) || !isWall(types.Coordinate{X: c.X, Y: c.Y - 2}, lvl) { // Only eat if at least the next 2 are walls
 				break
 			}
 			lvl[c.Y-1][c.X] = Empty{}
 			lvl[c.Y-2][c.X] = Empty{}
-			eat(types.Coordinate{c.X, c.Y - 2}, lvl)
+			eat(types.Coordinate{X: c.X, Y: c.Y - 2}, lvl)
 		case 4: // North
-			if c.Y >= height-3 || !isWall(types.Coordinate{c.X, c.Y + 1}, lvl) || !isWall(types.Coordinate{c.X, c.Y + 2}, lvl) { // Only eat if at least the next 2 are walls
+			if c.Y >= height-3 || !isWall(types.Coordinate{X: c.X, Y: c.Y + 1}, lvl) || !isWall(types.Coordinate{X: c.X, Y: c.Y + 2}, lvl) { // Only eat if at least the next 2 are walls
 				break
 			}
 			lvl[c.Y+1][c.X] = Empty{}
 			lvl[c.Y+2][c.X] = Empty{}
-			eat(types.Coordinate{c.X, c.Y + 2}, lvl)
+			eat(types.Coordinate{X: c.X, Y: c.Y + 2}, lvl)
 		default:
 			panic("Unknown direction")
 		}
@@ -169,16 +169,16 @@ func adjacent(w types.Coordinate, mapEdge bool) []types.Coordinate {
 		maxH = height - 2
 	}
 	if int(w.X)+1 <= maxW {
-		adj = append(adj, types.Coordinate{w.X + 1, w.Y})
+		adj = append(adj, types.Coordinate{X: w.X + 1, Y: w.Y})
 	}
 	if int(w.X)-1 >= min {
-		adj = append(adj, types.Coordinate{w.X - 1, w.Y})
+		adj = append(adj, types.Coordinate{X: w.X - 1, Y: w.Y})
 	}
 	if int(w.Y)+1 <= maxH {
-		adj = append(adj, types.Coordinate{w.X, w.Y + 1})
+		adj = append(adj, types.Coordinate{X: w.X, Y: w.Y + 1})
 	}
 	if int(w.Y)-1 >= min {
-		adj = append(adj, types.Coordinate{w.X, w.Y - 1})
+		adj = append(adj, types.Coordinate{X: w.X, Y: w.Y - 1})
 	}
 
 	return adj
@@ -197,16 +197,16 @@ func diagonal(w types.Coordinate, mapEdge bool) []types.Coordinate {
 		maxH = height - 2
 	}
 	if int(w.X)+1 <= maxW && int(w.Y)+1 <= maxH {
-		adj = append(adj, types.Coordinate{w.X + 1, w.Y + 1})
+		adj = append(adj, types.Coordinate{X: w.X + 1, Y: w.Y + 1})
 	}
 	if int(w.X)-1 >= min && int(w.Y)-1 >= min {
-		adj = append(adj, types.Coordinate{w.X - 1, w.Y - 1})
+		adj = append(adj, types.Coordinate{X: w.X - 1, Y: w.Y - 1})
 	}
 	if int(w.Y)+1 <= maxH && int(w.X)-1 >= min {
-		adj = append(adj, types.Coordinate{w.X - 1, w.Y + 1})
+		adj = append(adj, types.Coordinate{X: w.X - 1, Y: w.Y + 1})
 	}
 	if int(w.Y)-1 >= min && int(w.X)+1 <= maxW {
-		adj = append(adj, types.Coordinate{w.X + 1, w.Y - 1})
+		adj = append(adj, types.Coordinate{X: w.X + 1, Y: w.Y - 1})
 	}
 
 	return adj
@@ -227,7 +227,7 @@ func emptyAdjacent(c types.Coordinate, lvl [][]io.Runeable) int {
 }
 
 func randMapCoord() types.Coordinate {
-	return types.Coordinate{rand.Intn(width-1) + 1, rand.Intn(height-1) + 1}
+	return types.Coordinate{X: rand.Intn(width-1) + 1, Y: rand.Intn(height-1) + 1}
 }
 
 // walkToEmpty takes coordincate c and randomly walks till it finds an empty location
@@ -266,8 +266,6 @@ func walkToEmpty(c types.Coordinate, lvl [][]io.Runeable) types.Coordinate {
 			c.Y = height - 2
 		}
 	}
-
-	return c
 }
 
 // placeMultipleObjects places [0,N) objects of type o in lvl at random coordinates
@@ -343,10 +341,10 @@ func placeMapObjects(lvl uint, m [][]io.Runeable) {
 		}
 
 		if lvl <= 10 {
-			placeMultipleObjects(rand.Intn(2), func() io.Runeable { return &items.Gem{Stone: items.Diamond, Value: rand.Intn(10*int(lvl)+1) + 10} }, m)
-			placeMultipleObjects(rand.Intn(2), func() io.Runeable { return &items.Gem{Stone: items.Ruby, Value: rand.Intn(6*int(lvl)+1) + 6} }, m)
-			placeMultipleObjects(rand.Intn(2), func() io.Runeable { return &items.Gem{Stone: items.Emerald, Value: rand.Intn(4*int(lvl)+1) + 4} }, m)
-			placeMultipleObjects(rand.Intn(2), func() io.Runeable { return &items.Gem{Stone: items.Sapphire, Value: rand.Intn(3*int(lvl)+1) + 2} }, m)
+			for _, stone := range []int{items.Diamond, items.Ruby, items.Emerald, items.Sapphire} {
+				stone := stone
+				placeMultipleObjects(rand.Intn(2), func() io.Runeable { return items.CreateGemOf(stone, lvl) }, m)
+			}
 		}
 
 		placeMultipleObjects(rand.Intn(4)+4, func() io.Runeable { return items.NewPotion() }, m)
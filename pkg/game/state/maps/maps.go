@@ -6,6 +6,7 @@ import (
 
 	log "github.com/sirupsen/logrus"
 	"github.com/thorfour/larn/pkg/game/state/character"
+	"github.com/thorfour/larn/pkg/game/state/items"
 	"github.com/thorfour/larn/pkg/game/state/monster"
 	"github.com/thorfour/larn/pkg/game/state/types"
 	"github.com/thorfour/larn/pkg/io"
@@ -24,6 +25,7 @@ type Maps struct {
 	entrance []types.Coordinate   // list of all entrances in each maze (i.e where a ladder from the previous maze drops you)
 	active   [][]io.Runeable      // current active maze
 	current  int                  // index of the active maze. active = mazes[current]
+	explored [][][]bool           // coordinates the player has seen on each maze
 }
 
 // EnterLevel moves a character from one level to the next by way of entrance or stairs
@@ -58,6 +60,7 @@ func New(c *character.Character) *Maps {
 		}
 
 		m.mazes = append(m.mazes, nm)
+		m.explored = append(m.explored, newExplored())
 	}
 	m.active = m.mazes[homeLevel]
 	m.SpawnCharacter(m.entrance[homeLevel], c)
@@ -187,8 +190,10 @@ func (m *Maps) CurrentLevel() int { return m.current }
 func (m *Maps) SetVisible(c *character.Character) {
 
 	coord := c.Location()
+	m.explored[m.current][coord.Y][coord.X] = true
 	adj := append(adjacent(coord, false), diagonal(coord, false)...)
 	for _, l := range adj {
+		m.explored[m.current][l.Y][l.X] = true
 		switch m.active[l.Y][l.X].(type) {
 		case Visible:
 			m.active[l.Y][l.X].(Visible).Visible(true)
@@ -248,6 +253,78 @@ func (m *Maps) Distance(c0, c1 types.Coordinate) int {
 	return int(math.Abs(float64(c0.X-c1.X)) + math.Abs(float64(c0.Y-c1.Y)))
 }
 
+// newExplored returns an unexplored record of a maze
+func newExplored() [][]bool {
+	e := make([][]bool, height)
+	for y := range e {
+		e[y] = make([]bool, width)
+	}
+	return e
+}
+
+// Explored returns true if the player has seen the coordinate on the active maze
+func (m *Maps) Explored(c types.Coordinate) bool {
+	return m.ValidCoordinate(c) && m.explored[m.current][c.Y][c.X]
+}
+
+// ForgetExplored forgets everything the player has seen on the active maze
+func (m *Maps) ForgetExplored() {
+	m.explored[m.current] = newExplored()
+}
+
+// Path returns the shortest list of moves from one coordinate to another over displaceable tiles, avoiding found traps.
+// Returns nil if there is no way there
+func (m *Maps) Path(from, to types.Coordinate) []types.Direction {
+	return m.pathTo(from, func(c types.Coordinate) bool { return c == to })
+}
+
+// PathToUnexplored returns the shortest list of moves to the nearest displaceable tile the player hasn't seen.
+// Returns nil if there is nothing left to explore
+func (m *Maps) PathToUnexplored(from types.Coordinate) []types.Direction {
+	return m.pathTo(from, func(c types.Coordinate) bool { return !m.Explored(c) })
+}
+
+// pathTo returns the shortest list of moves to the closest coordinate satisfying goal, or nil if none can be reached
+func (m *Maps) pathTo(from types.Coordinate, goal func(types.Coordinate) bool) []types.Direction {
+	type step struct {
+		prev types.Coordinate
+		d    types.Direction
+	}
+
+	visited := map[types.Coordinate]step{from: {}}
+	queue := []types.Coordinate{from}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if c != from && goal(c) {
+			// Walk back from the destination
+			var path []types.Direction
+			for ; c != from; c = visited[c].prev {
+				path = append([]types.Direction{visited[c].d}, path...)
+			}
+			return path
+		}
+
+		for d := types.Up; d < types.Here; d++ {
+			next := types.Move(c, d)
+			if _, ok := visited[next]; ok || !m.ValidCoordinate(next) || m.OuterWall(next) {
+				continue
+			}
+			obj := m.active[next.Y][next.X]
+			if _, ok := obj.(Displaceable); !ok {
+				continue
+			}
+			if t, ok := obj.(*items.Trap); ok && t.Found && !goal(next) {
+				continue
+			}
+			visited[next] = step{prev: c, d: d}
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
 // At returns whatever is at the given location on the active map
 func (m *Maps) At(c types.Coordinate) io.Runeable {
 	return m.active[c.Y][c.X]
@@ -258,6 +335,11 @@ func (m *Maps) NewEmptyTile() Empty {
 	return Empty{m.current == homeLevel}
 }
 
+// Obscured returns an Empty tile in place of o, it's only displayed if o has been seen
+func Obscured(o io.Runeable) Empty {
+	return Empty{o.Rune() != invisbleRune}
+}
+
 // TouchAllInteriorCoordinates walks the maps internal coordinats, and executes the given function on them
 func (m *Maps) TouchAllInteriorCoordinates(f func(io.Runeable)) {
 	for x := 1; x < width-1; x++ {
@@ -2,6 +2,7 @@ package maps
 
 import (
 	termbox "github.com/nsf/termbox-go"
+	"github.com/thorfour/larn/pkg/io"
 )
 
 const (
@@ -93,6 +94,9 @@ func (e Empty) Fg() termbox.Attribute { return termbox.ColorDefault }
 // Bg implements the io.Runeable interface
 func (e Empty) Bg() termbox.Attribute { return termbox.ColorDefault }
 
+// Category implements the io.Categorized interface
+func (e Empty) Category() io.Category { return io.Floor }
+
 // Wall is a maze wall
 type Wall struct {
 	visible bool
@@ -115,6 +119,9 @@ func (w *Wall) Fg() termbox.Attribute { return termbox.ColorDefault }
 // Bg implements the io.Runeable interface
 func (w *Wall) Bg() termbox.Attribute { return termbox.ColorDefault }
 
+// Category implements the io.Categorized interface
+func (w *Wall) Category() io.Category { return io.Wall }
+
 // Stairs is a staircase
 type Stairs struct {
 	up      bool // indicates if these stairs go up
@@ -131,6 +138,14 @@ func (s *Stairs) Displace() bool { return true }
 // Enter implements the Enterable interface
 func (s *Stairs) Enter() int { return s.level }
 
+// Name returns the description of the stairs
+func (s *Stairs) Name() string {
+	if s.up {
+		return "staircase up"
+	}
+	return "staircase down"
+}
+
 // Rune implements the io.Runeable interface
 func (s *Stairs) Rune() rune {
 	if s.visible {
@@ -148,6 +163,9 @@ func (s *Stairs) Fg() termbox.Attribute { return termbox.ColorDefault }
 // Bg implements the io.Runeable interface
 func (s *Stairs) Bg() termbox.Attribute { return termbox.ColorDefault }
 
+// Category implements the io.Categorized interface
+func (s *Stairs) Category() io.Category { return io.Stairs }
+
 // Entrance type are the entrances that are on the home level
 type Entrance struct {
 	r         rune // entrnace rune to displace
@@ -165,10 +183,27 @@ func (e Entrance) Enter() int { return e.enterCode }
 func (e Entrance) Rune() rune { return e.r }
 
 // Fg implements the io.Runeable interface
-func (e Entrance) Fg() termbox.Attribute { return termbox.ColorBlack }
+func (e Entrance) Fg() termbox.Attribute { return termbox.ColorDefault }
 
 // Log implements the Loggable interface
 func (e Entrance) Log() string { return e.log }
 
+var entranceName = map[rune]string{
+	dungeonRune: "dungeon entrance",
+	homeRune:    "home",
+	collegeRune: "College of Larn",
+	lrsRune:     "LRS office",
+	tradeRune:   "trading post",
+	bankRune:    "bank of Larn",
+	dndRune:     "DND store",
+	volRune:     "volcanic shaft",
+}
+
+// Name returns the description of the entrance
+func (e Entrance) Name() string { return entranceName[e.r] }
+
 // Bg implements the io.Runeable interface
-func (e Entrance) Bg() termbox.Attribute { return termbox.ColorGreen }
+func (e Entrance) Bg() termbox.Attribute { return termbox.ColorDefault }
+
+// Category implements the io.Categorized interface
+func (e Entrance) Category() io.Category { return io.Entrance }
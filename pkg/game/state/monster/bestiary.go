@@ -0,0 +1,122 @@
+package monster
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Entry describes a kind of monster
+type Entry struct {
+	ID           int    `json:"id"`
+	Rune         string `json:"rune"`
+	Name         string `json:"name"`
+	Level        int    `json:"level"`
+	Armor        int    `json:"armor"`
+	Damage       int    `json:"damage"`
+	Attack       int    `json:"attack"`
+	Intelligence int    `json:"intelligence"`
+	Gold         int    `json:"gold"`
+	Hitpoints    int    `json:"hitpoints"`
+	Experience   int    `json:"experience"`
+	Notes        string `json:"notes,omitempty"` // special abilities
+}
+
+// attackNotes describes the special attack of each attack type
+var attackNotes = map[int]string{
+	1:  "rusts armor",
+	2:  "breathes fire",
+	3:  "breathes intense fire",
+	4:  "stings, sapping strength",
+	5:  "breathes frost",
+	6:  "drains experience levels",
+	7:  "blasts with water",
+	8:  "steals gold",
+	9:  "disenchants items",
+	10: "lashes with its tail",
+	11: "causes confusion",
+	12: "casts spells",
+	13: "causes dementia",
+	14: "steals items",
+	15: "bites",
+	16: "has a poisonous bite",
+}
+
+// Describe returns the entry for the monster id
+func Describe(id int) Entry {
+	m := monsterData[id]
+	e := Entry{
+		ID:           id,
+		Rune:         string(m.MonsterRune),
+		Name:         m.Name,
+		Level:        m.Lvl,
+		Armor:        m.Armor,
+		Damage:       m.Dmg,
+		Attack:       m.Attack,
+		Intelligence: m.Intelligence,
+		Gold:         m.Gold,
+		Hitpoints:    m.Hitpoints,
+		Experience:   m.Experience,
+		Notes:        attackNotes[m.Attack],
+	}
+
+	if m.MonsterRune == InvisibleRune {
+		e.Rune = ""
+		if e.Notes != "" {
+			e.Notes = "invisible, " + e.Notes
+		} else {
+			e.Notes = "invisible"
+		}
+	}
+	return e
+}
+
+// String returns the entry's stats as a single line, without the notes
+func (e Entry) String() string {
+	r := e.Rune
+	if r == "" {
+		r = " "
+	}
+	return fmt.Sprintf("%s %-19s lvl %2d  ac %4d  dmg %2d  hp %3d  exp %6d",
+		r, e.Name, e.Level, e.Armor, e.Damage, e.Hitpoints, e.Experience)
+}
+
+// Table returns an entry for every monster ordered by id
+func Table() []Entry {
+	ids := make([]int, 0, len(monsterData))
+	for id := range monsterData {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	table := make([]Entry, 0, len(ids))
+	for _, id := range ids {
+		table = append(table, Describe(id))
+	}
+	return table
+}
+
+// Bestiary is the per-game record of every monster the player has encountered
+type Bestiary struct {
+	Encountered map[int]bool `json:"encountered"` // ids of the monsters the player has seen
+}
+
+// NewBestiary returns an empty bestiary
+func NewBestiary() *Bestiary {
+	return &Bestiary{Encountered: make(map[int]bool)}
+}
+
+// Encounter records that the player has seen the monster id
+func (b *Bestiary) Encounter(id int) {
+	b.Encountered[id] = true
+}
+
+// Entries returns an entry for every encountered monster ordered by id
+func (b *Bestiary) Entries() []Entry {
+	var entries []Entry
+	for _, e := range Table() {
+		if b.Encountered[e.ID] {
+			entries = append(entries, e)
+		}
+	}
+	return entries
+}
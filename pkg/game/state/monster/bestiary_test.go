@@ -0,0 +1,25 @@
+package monster
+
+import "testing"
+
+// TestTable ensures the table covers every monster in order and notes special abilities
+func TestTable(t *testing.T) {
+	table := Table()
+	if len(table) != len(monsterData) {
+		t.Fatalf("unexpected table size %v", len(table))
+	}
+
+	for i := 1; i < len(table); i++ {
+		if table[i-1].ID >= table[i].ID {
+			t.Fatal("table isn't ordered by id")
+		}
+	}
+
+	if e := Describe(Invisiblestalker); e.Notes != "invisible" {
+		t.Errorf("unexpected notes %q", e.Notes)
+	}
+
+	if e := Describe(Rustmonster); e.Notes != "rusts armor" {
+		t.Errorf("unexpected notes %q", e.Notes)
+	}
+}
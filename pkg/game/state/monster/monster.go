@@ -43,6 +43,9 @@ func (m *Monster) Bg() termbox.Attribute { return termbox.ColorDefault }
 // Fg implements the io.Runeable interface
 func (m *Monster) Fg() termbox.Attribute { return termbox.ColorDefault }
 
+// Category implements the io.Categorized interface
+func (m *Monster) Category() io.Category { return io.Monster }
+
 // Visible implements the Visibility interface
 func (m *Monster) Visible(v bool) { m.Visibility = v }
 
@@ -62,6 +65,27 @@ func (m *Monster) Damage(dmg int) (int, bool) {
 // Name returns the name of the monster
 func (m *Monster) Name() string { return NameFromID(m.id) }
 
+// Health returns a rough description of how hurt the monster is
+func (m *Monster) Health() string {
+	max := monsterData[m.id].Hitpoints
+	if max <= 0 {
+		return "unhurt"
+	}
+
+	switch pct := m.Info.Hitpoints * 100 / max; {
+	case pct >= 100:
+		return "unhurt"
+	case pct >= 66:
+		return "lightly wounded"
+	case pct >= 33:
+		return "wounded"
+	case pct >= 10:
+		return "badly wounded"
+	default:
+		return "almost dead"
+	}
+}
+
 // New returns a new Monster from a monster id
 func New(monster int) *Monster {
 	return &Monster{
@@ -71,6 +95,14 @@ func New(monster int) *Monster {
 	}
 }
 
+// Revealed displays an invisible monster to a player that can see invisible
+type Revealed struct {
+	*Monster
+}
+
+// Rune implements the io.Runeable interface
+func (r Revealed) Rune() rune { return 'I' }
+
 // Empty represents an empty map location
 type Empty struct {
 	visible bool
@@ -96,6 +128,9 @@ func (e Empty) Fg() termbox.Attribute { return termbox.ColorDefault }
 // Bg implements the io.Runeable interface
 func (e Empty) Bg() termbox.Attribute { return termbox.ColorDefault }
 
+// Category implements the io.Categorized interface
+func (e Empty) Category() io.Category { return io.Floor }
+
 // BaseDamage returns the base damage of a monster
 func (m *Monster) BaseDamage() int {
 	switch m.id {
@@ -12,6 +12,7 @@ import (
 	"github.com/thorfour/larn/pkg/game/state/items"
 	"github.com/thorfour/larn/pkg/game/state/maps"
 	"github.com/thorfour/larn/pkg/game/state/monster"
+	"github.com/thorfour/larn/pkg/game/state/store"
 	"github.com/thorfour/larn/pkg/game/state/types"
 	"github.com/thorfour/larn/pkg/io"
 )
@@ -19,6 +20,17 @@ import (
 const (
 	logLength = 5     // Ideally should be the same as the game.logLength but is useful to be definde separately for debug
 	timeLimit = 30000 // max time to win a game
+
+	vaporizeAttr = 10  // enchanting equipment at or past this attribute risks vaporizing it
+	dullLimit    = -10 // weapons can't be dulled past this attribute
+
+	searchChance = 25   // percent chance to find a hidden trap when searching, wisdom adds to the chance
+	maxRest      = 1000 // most turns the player can rest at once
+	nearby       = 5    // monsters this close to the player interrupt resting and running
+	maxExplore   = 1000 // most steps the player can auto-explore at once
+
+	taxRate     = 20 // winners owe 1/taxRate of their winnings in taxes
+	latePenalty = 10 // percent added to taxes left unpaid from a previous game
 )
 
 var (
@@ -26,6 +38,12 @@ var (
 	ErrAlreadyDisplacedErr = fmt.Errorf("There's something here already")
 	// ErrDidntWork player failed to cast a spell
 	ErrDidntWork = fmt.Errorf("  It didn't seem to work")
+	// ErrNoAltar player tried to pray without an altar
+	ErrNoAltar = fmt.Errorf("There is no altar here")
+	// ErrNotEnoughGold player can't afford a purchase
+	ErrNotEnoughGold = fmt.Errorf("You don't have enough gold to pay for that!")
+	// ErrNotThatMuch player tried to spend more gold than they have
+	ErrNotThatMuch = fmt.Errorf("You don't have that much.")
 )
 
 type logring []string
@@ -42,14 +60,22 @@ func (log logring) add(s string) logring {
 // State holds all current game state
 type State struct {
 	StatLog    logring
+	History    []Message // every message logged this game
+	unread     int       // number of messages at the end of the history the player hasn't been shown
 	C          *character.Character
+	Known      *items.Knowledge  // everything the player has identified this game
+	Store      *store.Store      // the DND store's stock this game
+	Seen       *monster.Bestiary // every monster the player has encountered this game
 	Active     map[string]func()
 	maps       *maps.Maps
 	rng        *rand.Rand
-	Taxes      int
+	Taxes      int  // taxes owed to the LRS
+	Penalized  bool // the LRS has charged the late penalty this game
 	Name       string
 	timeUsed   uint
 	difficulty int
+	regenCount int // turns until the player next regains hp
+	spellCount int // turns until the player next regains a spell
 }
 
 // New returns a new state and prints the welcome screen
@@ -59,47 +85,308 @@ func New(diff int) *State {
 	s.difficulty = diff
 	s.C = new(character.Character)
 	s.C.Init(s.difficulty)
+	s.Known = items.NewKnowledge()
+	s.C.IdentifyInventory(s.Known) // the player knows their starting gear
+	s.Store = store.New()
+	s.Seen = monster.NewBestiary()
 	s.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
 	s.maps = maps.New(s.C)
 
 	// Display the welcome string at the bottom
 	for i := 0; i < logLength-1; i++ {
-		s.Log("")
+		s.StatLog = s.StatLog.add("")
 	}
 	s.Log("Welcome to larn -- Press ? for help")
 	return s
 }
 
-// Drop drops an item where the player is standing, returns false if the player is already standing on an item
-func (s *State) Drop(e rune) (items.Item, error) {
+// Drop drops the items in slots e as a single action. The first lands where the player is standing and the rest on empty
+// tiles next to them. Returns the items that were dropped before any error
+func (s *State) Drop(e ...rune) ([]items.Item, error) {
 	defer s.update()
-	if _, ok := s.C.Displaced.(maps.Empty); !ok { // Check if player is already displacing an object
-		return nil, ErrAlreadyDisplacedErr
+
+	var dropped []items.Item
+	for _, r := range e {
+		// Items that don't fit where the player is standing are dropped next to them
+		c := s.C.Location()
+		if _, ok := s.C.Displaced.(maps.Empty); !ok {
+			var found bool
+			if c, found = s.emptyAdjacent(c); !found {
+				return dropped, ErrAlreadyDisplacedErr
+			}
+		}
+
+		item, err := s.C.DropItem(r)
+		if err != nil {
+			return dropped, s.learnCurse(err)
+		}
+		dropped = append(dropped, item)
+
+		if c == s.C.Location() {
+			s.C.Displaced = item
+			continue
+		}
+		if v, ok := item.(types.Visibility); ok {
+			v.Visible(true)
+		}
+		s.maps.CurrentMap()[c.Y][c.X] = item
 	}
+	return dropped, nil
+}
 
-	item, err := s.C.DropItem(e)
-	if err != nil {
-		return nil, err
+// DropGold drops n gold pieces where the player is standing. Gold dropped on an altar is a contribution to the gods
+func (s *State) DropGold(n int) error {
+	if s.C.Stats.Gold < uint(n) {
+		return ErrNotThatMuch
+	}
+
+	if n <= 0 {
+		return nil
+	}
+
+	switch s.C.Displaced.(type) {
+	case *items.Altar:
+		return s.contribute(n)
+	case maps.Empty:
+	default: // Check if player is already displacing an object
+		return ErrAlreadyDisplacedErr
+	}
+
+	defer s.update()
+	s.C.Stats.Gold -= uint(n)
+	gp := &items.GoldPile{Amount: n}
+	gp.Visible(true)
+	s.C.Displaced = gp
+	s.LogAs(Item, fmt.Sprintf("You drop %v gold pieces", n))
+	return nil
+}
+
+// Wield has the player wield a weapon
+func (s *State) Wield(e rune) error {
+	return s.learnCurse(s.C.Wield(e))
+}
+
+// Wear has the player wear armor
+func (s *State) Wear(e rune) error {
+	return s.learnCurse(s.C.Wear(e))
+}
+
+// TakeOff has the player take off their armor
+func (s *State) TakeOff() error {
+	return s.learnCurse(s.C.TakeOff())
+}
+
+// Disarm has the player put down their weapon
+func (s *State) Disarm() error {
+	return s.learnCurse(s.C.Disarm())
+}
+
+// learnCurse reveals the curse on an item that refused to be removed. Returns the given error
+func (s *State) learnCurse(err error) error {
+	if c, ok := err.(*character.CursedError); ok {
+		if a, ok := c.Item.(items.Attributable); ok {
+			s.Known.LearnCurse(a)
+		}
+	}
+	return err
+}
+
+// Pray at the altar the player is standing on
+func (s *State) Pray() error {
+	if _, ok := s.C.Displaced.(*items.Altar); !ok {
+		return ErrNoAltar
+	}
+	defer s.update()
+
+	switch {
+	case rand.Intn(100) < 75:
+		s.Log("Nothing happens")
+	case rand.Intn(13) < 4:
+		s.altarBlessing()
+	case rand.Intn(43) == 10:
+		s.enchantArmor()
+	case rand.Intn(43) == 10:
+		s.enchantWeapon()
+	default:
+		s.createMonster(s.maps.CurrentLevel() + 1)
+	}
+	return nil
+}
+
+// contribute n gold pieces to the altar the player is standing on. Stingy contributions anger the gods
+func (s *State) contribute(n int) error {
+	defer s.update()
+	stingy := s.stingy(n)
+	s.C.Stats.Gold -= uint(n)
+
+	switch {
+	case stingy:
+		s.createMonster(s.maps.CurrentLevel() + 1)
+	case rand.Intn(101) > 50:
+		s.altarBlessing()
+	case rand.Intn(43) == 5:
+		s.enchantArmor()
+	case rand.Intn(43) == 8:
+		s.enchantWeapon()
+	default:
+		s.Log("Thank You.")
+	}
+	return nil
+}
+
+// stingy returns true if contributing n gold pieces is less than the gods expect from the player's gold
+func (s *State) stingy(n int) bool {
+	return n < int(s.C.Stats.Gold/10) || n < rand.Intn(50)
+}
+
+// altarBlessing the gods have heard the player. They protect the player and lift the curses from their pack
+func (s *State) altarBlessing() {
+	s.Log("You have been heard!")
+	if !s.C.Cond.EffectActive(conditions.AltarProtection) {
+		s.C.Stats.Ac += 3
+	}
+	s.C.Cond.Refresh(conditions.AltarProtection, 500, 3)
+	if s.C.RemoveCurses() > 0 {
+		s.Log("You feel as if a weight has been lifted")
+	}
+}
+
+// enchantWeapon raises the attribute of the wielded weapon, over-enchanted weapons may vaporize
+func (s *State) enchantWeapon() {
+	a, ok := s.C.Wielding().(items.Attributable)
+	if !ok {
+		s.LogAs(Item, "You feel a sense of loss")
+		return
+	}
+
+	if a.Attr() >= vaporizeAttr && rand.Intn(10) < 9 {
+		s.C.DestroyWeapon()
+		s.LogAs(Item, "Your weapon vibrates violently and then vaporizes!")
+		return
 	}
-	s.C.Displaced = item
-	return item, nil
+
+	s.C.EnchantWeapon(1)
+	s.LogAs(Item, "Your weapon glows for a moment")
+}
+
+// enchantArmor raises the attribute of the worn armor, or the shield if no armor is worn. Over-enchanted armor may vaporize
+func (s *State) enchantArmor() {
+	a, ok := s.C.Wearing().(items.Attributable)
+	if !ok {
+		s.LogAs(Item, "You feel a sense of loss")
+		return
+	}
+
+	name := "armor"
+	if _, ok := a.(*items.Shield); ok {
+		name = "shield"
+	}
+
+	if a.Attr() >= vaporizeAttr && rand.Intn(10) < 9 {
+		s.C.DestroyArmor()
+		s.LogAs(Item, fmt.Sprintf("Your %s vibrates violently and then vaporizes!", name))
+		return
+	}
+
+	s.C.EnchantArmor(1)
+	s.LogAs(Item, fmt.Sprintf("Your %s glows for a moment", name))
 }
 
-// Log adds the string to the statlog
-func (s *State) Log(str string) {
-	s.StatLog = s.StatLog.add(str)
+// createMonster spawns a monster of the given level in a random empty location next to the player
+func (s *State) createMonster(l int) {
+	coords := s.maps.AdjacentCoords(s.C.Location())
+	rand.Shuffle(len(coords), func(i, j int) {
+		tmp := coords[j]
+		coords[j] = coords[i]
+		coords[i] = tmp
+	})
+
+	for _, c := range coords {
+		if _, ok := s.maps.At(c).(maps.Displaceable); ok { // Found a displaceable object to place the monster onto
+			mon := monster.New(monster.FromLevel(l))
+			mon.Visible(true)
+			// TODO in the case of ROTHE, POLTERGEIST OR VAMPIRE stealth needs to be set on the monster
+			// TODO figure out how monster stealth is utilized
+			mon.Displaced = s.maps.Swap(c, mon)
+			return
+		}
+	}
 }
 
 // CurrentMap returns the current map the character is on
 func (s *State) CurrentMap() [][]io.Runeable {
-	return s.maps.CurrentMap()
+	m := s.maps.CurrentMap()
+	blind := s.C.Cond.EffectActive(conditions.Blindness)
+	seeInvisible := s.C.Cond.EffectActive(conditions.SeeInvisible)
+	if !blind && !seeInvisible {
+		return m
+	}
+
+	// Show the map as the player sees it. Blind players can't see monsters or items, and only feel the floor beneath them.
+	// Invisible stalkers only appear with see invisible
+	view := make([][]io.Runeable, len(m))
+	for y := range m {
+		view[y] = make([]io.Runeable, len(m[y]))
+		for x, obj := range m[y] {
+			view[y][x] = obj
+			switch t := obj.(type) {
+			case *monster.Monster:
+				switch {
+				case blind:
+					view[y][x] = maps.Obscured(t.Displaced)
+				case t.ID() == monster.Invisiblestalker && t.Visibility:
+					view[y][x] = monster.Revealed{Monster: t}
+				}
+			case items.Item:
+				if blind {
+					view[y][x] = maps.Obscured(t)
+				}
+			}
+		}
+	}
+	return view
+}
+
+// look reveals the objects surrounding the player, unless they're blind
+func (s *State) look() {
+	if s.C.Cond.EffectActive(conditions.Blindness) {
+		return
+	}
+	s.maps.SetVisible(s.C)
+	s.encounter()
+}
+
+// encounter records every monster the player can currently see in the bestiary
+func (s *State) encounter() {
+	for _, row := range s.CurrentMap() {
+		for _, obj := range row {
+			switch m := obj.(type) {
+			case monster.Revealed:
+				s.Seen.Encounter(m.ID())
+			case *monster.Monster:
+				if m.Rune() != monster.InvisibleRune {
+					s.Seen.Encounter(m.ID())
+				}
+			}
+		}
+	}
+}
+
+// confused returns the direction a move actually goes. Confused players stumble in a random direction, less often as they gain levels
+func (s *State) confused(d types.Direction) types.Direction {
+	if s.C.Cond.EffectActive(conditions.Confusion) && int(s.C.Stats.Level) < rand.Intn(30) {
+		return types.Direction(rand.Intn(int(types.Here)))
+	}
+	return d
 }
 
 // Move is for character movement
 func (s *State) Move(d types.Direction) bool {
-	defer s.maps.SetVisible(s.C)
+	defer s.look()
 	defer s.update()
 
+	d = s.confused(d)
+
 	// Move the character
 	moved, attacked := s.maps.Move(d, s.C)
 
@@ -109,15 +396,30 @@ func (s *State) Move(d types.Direction) bool {
 		return false
 	}
 
-	// If the character is displacing something add it to the status log
 	if moved {
+		// Carrying too much slows the character down, monsters get extra turns
+		for i := 0; i < int(s.C.Burden()); i++ {
+			s.update()
+		}
+
+		// If the character is displacing something add it to the status log
 		switch t := s.C.Displaced.(type) {
+		case *items.Trap:
+			t.Found = true // stepping on a trap reveals it
+			t.Visible(true)
+			s.LogAs(Item, t.Log())
 		case *items.GoldPile:
 			t.PickUp(s.C.Stats) // auto-pick up gold
 			s.C.Displaced = s.maps.NewEmptyTile()
-			s.Log(t.Log())
+			s.LogAs(Item, t.Log(s.Known))
+		case items.Item:
+			if s.C.Cond.EffectActive(conditions.Blindness) {
+				s.LogAs(Item, "You feel something here")
+				break
+			}
+			s.LogAs(Item, t.Log(s.Known))
 		case maps.Loggable:
-			s.Log(t.Log())
+			s.LogAs(Item, t.Log())
 		}
 	}
 	return moved
@@ -147,16 +449,78 @@ func (s *State) PickUp() {
 
 	i, ok := s.C.Displaced.(items.Item)
 	if ok {
-		i.PickUp(s.C.Stats)
-		s.C.AddItem(i)
+		if _, err := s.C.AddItem(i); err != nil {
+			s.Log(err.Error())
+			return
+		}
 		s.C.Displaced = s.maps.NewEmptyTile()
 	}
 }
 
+// PackWeight returns the displayable weight of the players pack
+func (s *State) PackWeight() string {
+	str := fmt.Sprintf("The stuff you are carrying presently weighs %v pounds, you can carry %v", s.C.PackWeight(), s.C.Capacity())
+	if b := s.C.Burden(); b != character.Unburdened {
+		str += fmt.Sprintf(". You are %s", b)
+	}
+	return str
+}
+
 // Inventory request
 func (s *State) Inventory() []string {
 	log.Debug("inventory request")
-	return s.C.Inventory()
+	return s.C.Inventory(s.Known)
+}
+
+// Discoveries lists the potions and scrolls the player has identified along with the spells they know
+func (s *State) Discoveries() []string {
+	lines := []string{"Potions:"}
+	for _, name := range s.Known.DiscoveredPotions() {
+		lines = append(lines, "  "+name)
+	}
+	lines = append(lines, "Scrolls:")
+	for _, name := range s.Known.DiscoveredScrolls() {
+		lines = append(lines, "  "+name)
+	}
+	lines = append(lines, "Spells:")
+	for _, spell := range items.KnownSpells(s.C.Stats.KnownSpells) {
+		lines = append(lines, fmt.Sprintf("  %s %s: %s", spell.Code, spell.Name, spell.Desc))
+	}
+	return lines
+}
+
+// Bestiary lists the stats of every monster the player has encountered
+func (s *State) Bestiary() []string {
+	lines := []string{"Monsters encountered:"}
+	for _, e := range s.Seen.Entries() {
+		lines = append(lines, "  "+e.String())
+		if e.Notes != "" {
+			lines = append(lines, "      "+e.Notes)
+		}
+	}
+	return lines
+}
+
+// InventoryIf returns the players inventory filtered by f
+func (s *State) InventoryIf(f func(items.Item) bool) []string {
+	log.Debug("filtered inventory request")
+	return s.C.InventoryIf(s.Known, f)
+}
+
+// Eat is for the player to eat food
+func (s *State) Eat(e rune) error {
+	defer s.update()
+	log.Debug("eat request")
+
+	l, err := s.C.Eat(e)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range l {
+		s.LogAs(Item, r)
+	}
+	return nil
 }
 
 // TimeStr returns the current time elapsed in the game
@@ -179,14 +543,40 @@ func (s *State) Read(e rune) error {
 	defer s.update()
 	log.Debug("read request")
 
-	l, err := s.C.Read(e)
+	l, i, err := s.C.Read(e)
 	if err != nil {
 		return err
 	}
 
 	// Log all the information that read returned
 	for _, r := range l {
-		s.Log(r)
+		s.LogAs(Item, r)
+	}
+
+	// Special scroll cases
+	if sc, ok := i.(*items.Scroll); ok {
+		s.Known.LearnScroll(sc.ID)
+		switch sc.ID {
+		case items.Identify:
+			s.C.IdentifyInventory(s.Known)
+			s.LogAs(Item, "Your pack glows for a moment")
+		case items.GemPerfection:
+			gems := s.C.Gems()
+			for _, g := range gems {
+				g.Perfect()
+			}
+			if len(gems) > 0 {
+				s.LogAs(Item, "Your gems sparkle brilliantly")
+			}
+		case items.EnchantWeapon:
+			s.enchantWeapon()
+		case items.EnchantArmor:
+			s.enchantArmor()
+		case items.RemoveCurse:
+			if s.C.RemoveCurses() > 0 {
+				s.LogAs(Item, "You feel as if a weight has been lifted")
+			}
+		}
 	}
 
 	return nil
@@ -220,7 +610,7 @@ func (s *State) Cast(spell string) (func(types.Direction) bool, error) {
 		if !s.C.Cond.EffectActive(conditions.SpellOfProtection) {
 			s.C.Stats.Ac += 2 // protection field +2
 		}
-		s.C.Cond.Refresh(conditions.SpellOfProtection, 250, func() { s.C.Stats.Ac -= 2 })
+		s.C.Cond.Refresh(conditions.SpellOfProtection, 250, 2)
 	case "mle": // magic missile
 		msg := "Your missile hit the %s"
 		if s.C.Stats.Level >= 2 {
@@ -232,12 +622,12 @@ func (s *State) Cast(spell string) (func(types.Direction) bool, error) {
 		if !s.C.Cond.EffectActive(conditions.SpellOfDexterity) {
 			s.C.Stats.Dex += 3
 		}
-		s.C.Cond.Refresh(conditions.SpellOfDexterity, 400, func() { s.C.Stats.Dex -= 3 })
+		s.C.Cond.Refresh(conditions.SpellOfDexterity, 400, 3)
 	case "sle": // sleep
 		hits := rand.Intn(3) + 2
 		return s.directedHit(sp, s.hits(hits), fmt.Sprintf("While the %s slept, you smashed it %d times", "%s", hits)), nil
 	case "chm": // charm monsters
-		s.C.Cond.Refresh(conditions.CharmMonsters, int(s.C.Stats.Cha)<<1, nil)
+		s.C.Cond.Refresh(conditions.CharmMonsters, int(s.C.Stats.Cha)<<1, 0)
 	case "ssp": // sonic spear
 		dmg := rand.Intn(10) + 16 + int(s.C.Stats.Level)
 		return s.projectile(sp, dmg, "The sound damages the %s", '@'), nil
@@ -251,7 +641,7 @@ func (s *State) Cast(spell string) (func(types.Direction) bool, error) {
 		if !s.C.Cond.EffectActive(conditions.SpellOfStrength) {
 			s.C.Stats.Str += 3
 		}
-		s.C.Cond.Add(conditions.SpellOfStrength, 150+rand.Intn(100), func() { s.C.Stats.Str -= 3 })
+		s.C.Cond.Add(conditions.SpellOfStrength, 150+rand.Intn(100), 3)
 	case "enl": // enlightenment
 		s.maps.TouchAllInteriorCoordinates(func(obj io.Runeable) {
 			if _, ok := obj.(types.Visibility); ok {
@@ -263,35 +653,18 @@ func (s *State) Cast(spell string) (func(types.Direction) bool, error) {
 	case "cbl": // cure blindness
 		s.C.Cond.Remove(conditions.Blindness)
 	case "cre": // create monster
-		// Select a random empty location next to the player to spawn the monster
-		coords := s.maps.AdjacentCoords(s.C.Location())
-		rand.Shuffle(len(coords), func(i, j int) {
-			tmp := coords[j]
-			coords[j] = coords[i]
-			coords[i] = tmp
-		})
-
-		for _, c := range coords {
-			if _, ok := s.maps.At(c).(maps.Displaceable); ok { // Found a displaceable object to place the monster onto
-				mon := monster.New(monster.FromLevel(s.maps.CurrentLevel() + 1))
-				mon.Visible(true)
-				// TODO in the case of ROTHE, POLTERGEIST OR VAMPIRE stealth needs to be set on the monster
-				// TODO figure out how monster stealth is utilized
-				mon.Displaced = s.maps.Swap(c, mon)
-				return nil, nil
-			}
-		}
+		s.createMonster(s.maps.CurrentLevel() + 1)
 	case "pha": // phantasmal forces
 		if rand.Intn(11)+8 <= int(s.C.Stats.Wisdom) {
 			return s.directedHit(sp, rand.Intn(20)+21+int(s.C.Stats.Level), "The %s believed!"), nil
 		}
-		s.Log("It didn't believe the illusions!")
+		s.LogAs(Combat, "It didn't believe the illusions!")
 	case "inv": // invsibility
 		n := 0
 		if am := s.C.CarryingSpecial(items.Amulet); am != nil { // Time added for amulet of invisibility
 			n += 1 + am.Attr()
 		}
-		s.C.Cond.Refresh(conditions.Invisiblity, (n<<7)+12, nil)
+		s.C.Cond.Refresh(conditions.Invisiblity, (n<<7)+12, 0)
 		//----------------------------------------------------------------------------
 		//                            LEVEL 3 SPELLS
 		//----------------------------------------------------------------------------
@@ -304,9 +677,9 @@ func (s *State) Cast(spell string) (func(types.Direction) bool, error) {
 	case "ply": // polymorph
 		return s.directedPolymorph(), nil
 	case "can": // cancellation
-		s.C.Cond.Refresh(conditions.Cancellation, 5+int(s.C.Stats.Level), nil)
+		s.C.Cond.Refresh(conditions.Cancellation, 5+int(s.C.Stats.Level), 0)
 	case "has": // haste self
-		s.C.Cond.Refresh(conditions.HasteSelf, 7+int(s.C.Stats.Level), nil)
+		s.C.Cond.Refresh(conditions.HasteSelf, 7+int(s.C.Stats.Level), 0)
 	case "ckl": // cloud kill
 		s.omniDirect(sp, 31+rand.Intn(10), "The %s gasps for air")
 	case "vpr": // vaporize rock
@@ -336,7 +709,7 @@ func (s *State) Cast(spell string) (func(types.Direction) bool, error) {
 		if s.C.Stats.Intelligence > 3 { // globe decreases intelligence to minimum of 3
 			s.C.Stats.Intelligence--
 		}
-		s.C.Cond.Add(conditions.GlobeOfInvul, 200, func() { s.C.Stats.Ac -= 10 })
+		s.C.Cond.Add(conditions.GlobeOfInvul, 200, 10)
 	case "flo": // flood
 		s.omniDirect(sp, 32+int(s.C.Stats.Level), "The %s struggles for air in your flood!")
 	case "fgr": // finger of death
@@ -356,11 +729,11 @@ func (s *State) Cast(spell string) (func(types.Direction) bool, error) {
 		//                            LEVEL 5 SPELLS
 		//----------------------------------------------------------------------------
 	case "sca": // scare monster
-		s.C.Cond.Refresh(conditions.ScareMonster, rand.Intn(9)+1+int(s.C.Stats.Level), nil)
+		s.C.Cond.Refresh(conditions.ScareMonster, rand.Intn(9)+1+int(s.C.Stats.Level), 0)
 	case "hld": // hold monsters
-		s.C.Cond.Add(conditions.HoldMonsters, rand.Intn(9)+1+int(s.C.Stats.Level), nil)
+		s.C.Cond.Add(conditions.HoldMonsters, rand.Intn(9)+1+int(s.C.Stats.Level), 0)
 	case "stp": // time stop
-		s.C.Cond.Add(conditions.TimeStop, rand.Intn(19)+1+(int(s.C.Stats.Level)<<1), nil)
+		s.C.Cond.Add(conditions.TimeStop, rand.Intn(19)+1+(int(s.C.Stats.Level)<<1), 0)
 	case "tel": // teleport away
 		return s.directedTeleport(), nil
 	case "mfi": // magic fire
@@ -381,41 +754,107 @@ func (s *State) Cast(spell string) (func(types.Direction) bool, error) {
 	return nil, nil
 }
 
-// IdentTrap notifies the player if there are traps adjacent
-func (s *State) IdentTrap() {
+// Search looks for traps adjacent to the player. Each hidden trap has a chance of being found
+func (s *State) Search() {
 	defer s.update()
 
-	// Get adjacent spaces
-	adj := s.maps.Adjacent(s.C.Location())
-
-	// Check all loc for traps
 	var found bool
-	for _, l := range adj {
-		if t, ok := l.(*items.Trap); ok {
-			switch t.TrapType {
-			case items.TeleTrap:
-				s.Log("It's a teleport trap")
-			case items.ArrowTrap:
-				s.Log("It's an arrow trap")
-			case items.DartTrap:
-				s.Log("It's an dart trap")
-			case items.DoorTrap:
-				s.Log("It's a trapdoor")
-			}
-			found = true
+	for _, l := range s.maps.Adjacent(s.C.Location()) {
+		t, ok := l.(*items.Trap)
+		if !ok {
+			continue
+		}
+
+		if !t.Found && rand.Intn(100) >= searchChance+int(s.C.Stats.Wisdom) {
+			continue
 		}
+
+		t.Found = true
+		t.Visible(true)
+		s.Log(fmt.Sprintf("It's a %s", t.Name()))
+		found = true
 	}
 
 	if !found {
-		s.Log("No traps are visible")
+		s.Log("You don't find any traps")
 	}
 }
 
+// Rest passes up to n turns in place. Returns the number of turns rested
+func (s *State) Rest(n int) int {
+	return s.rest(n, func() bool { return false })
+}
+
+// RestUntilHealed passes turns in place until the player has regained all their hp and spells. Returns the number of turns rested
+func (s *State) RestUntilHealed() int {
+	st := s.C.Stats
+	return s.rest(maxRest, func() bool { return st.Hp >= st.MaxHP && st.Spells >= st.MaxSpells })
+}
+
+// rest passes up to n turns until done returns true. Resting is interrupted when a monster comes into view,
+// the player is hit or a condition wears off
+func (s *State) rest(n int, done func() bool) int {
+	seen := s.nearbyMonsters()
+	for i := 0; i < n; i++ {
+		if done() {
+			return i
+		}
+
+		hp := s.C.Stats.Hp
+		active := s.C.Cond.Active()
+		s.update()
+		s.look()
+
+		if s.interrupted(hp, seen) {
+			return i + 1
+		}
+
+		for _, e := range active {
+			if !s.C.Cond.EffectActive(e.Condition) {
+				s.Log(fmt.Sprintf("Your %s has worn off", e.Condition))
+				return i + 1
+			}
+		}
+	}
+	return n
+}
+
+// interrupted returns true if the player was hit since having hp, or more than seen monsters are nearby
+func (s *State) interrupted(hp uint, seen int) bool {
+	switch {
+	case s.C.Stats.Hp < hp:
+		s.Log("You are interrupted!")
+		return true
+	case s.nearbyMonsters() > seen:
+		s.Log("You see a monster nearby!")
+		return true
+	}
+	return false
+}
+
+// nearbyMonsters returns the number of monsters close by that the player can see
+func (s *State) nearbyMonsters() int {
+	if s.C.Cond.EffectActive(conditions.Blindness) {
+		return 0
+	}
+
+	c := s.C.Location()
+	c1 := types.Coordinate{X: int(c.X) - nearby, Y: int(c.Y) - nearby}
+	c2 := types.Coordinate{X: int(c.X) + nearby, Y: int(c.Y) + nearby}
+	n := 0
+	for _, m := range s.monstersInWindow(c1, c2) {
+		if s.maps.At(m).Rune() != monster.InvisibleRune {
+			n++
+		}
+	}
+	return n
+}
+
 // update function to handle time passage, spell decay and monster movement
 func (s *State) update() {
 	log.Debug("updating game state")
 	if s.C.Cond.EffectActive(conditions.TimeStop) {
-		s.C.Cond.Decay(conditions.TimeStop) // time stop, only thing to do is decay that spell
+		s.C.Cond.Decay(conditions.TimeStop, s.C.Stats) // time stop, only thing to do is decay that spell
 		return
 	}
 
@@ -426,7 +865,59 @@ func (s *State) update() {
 	s.timeUsed++
 
 	// Decay all active functions
-	s.C.Cond.DecayAll()
+	s.C.Cond.DecayAll(s.C.Stats)
+
+	// The player heals and regains spells over time
+	s.regenerate()
+
+	// The DND store restocks over time
+	s.Store.Restock(s.timeUsed)
+}
+
+// regenerate restores the players hp and spells as turns pass. Higher level, constitution and intelligence
+// shorten the wait, as do regeneration and energy rings. Poison stops hp from coming back
+func (s *State) regenerate() {
+	st := s.C.Stats
+	if st.Hp < st.MaxHP && !s.C.Cond.EffectActive(conditions.HalfDamage) {
+		s.regenCount--
+		if s.regenCount <= 0 {
+			s.regenCount = 22 + (s.difficulty << 1) - int(st.Level) - int(st.Con)/3
+			if n := 1 + st.Regen; n > 0 {
+				st.GainHP(uint(n))
+			}
+		}
+	}
+
+	if st.Spells < st.MaxSpells {
+		s.spellCount--
+		if s.spellCount <= 0 {
+			s.spellCount = 100 + 4*(s.difficulty-int(st.Level)-st.Energy) - int(st.Intelligence)
+			st.Spells++
+		}
+	}
+}
+
+// Buy purchases the item at index r on page n of the DND store. Returns the item and the inventory slot it was placed in
+func (s *State) Buy(n int, r rune) (items.Item, rune, error) {
+	l, err := s.Store.Listing(n, r, s.C.Stats.Cha)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if s.C.Stats.Gold < uint(l.Price) { // unable to purchase the item
+		return nil, 0, ErrNotEnoughGold
+	}
+
+	if err := s.C.CanCarry(l.Item); err != nil {
+		return nil, 0, err
+	}
+
+	// Purchase the item
+	s.Store.Take(n, r)
+	s.C.Stats.Gold -= uint(l.Price)
+	s.Known.Identify(l.Item) // store bought items are always identified
+	slot, err := s.C.AddItem(l.Item)
+	return l.Item, slot, err
 }
 
 func (s *State) moveMonsters() {
@@ -443,8 +934,8 @@ func (s *State) moveMonsters() {
 	// Create a window from the current players position
 	// c1 is the bottom left coordindate of a square, and c2 is the top right
 	c := s.C.Location()
-	c1 := types.Coordinate{int(c.X) - 5, int(c.Y) - 3}
-	c2 := types.Coordinate{int(c.X) + 6, int(c.X) + 4}
+	c1 := types.Coordinate{X: int(c.X) - 5, Y: int(c.Y) - 3}
+	c2 := types.Coordinate{X: int(c.X) + 6, Y: int(c.X) + 4}
 
 	// Get a list of all monsters that appear in that window
 	monsters := s.monstersInWindow(c1, c2)
@@ -479,7 +970,7 @@ func (s *State) monstersInWindow(c1, c2 types.Coordinate) []types.Coordinate {
 		for j := c1.X; j <= c2.X; j++ {
 
 			// Current coordinate within the window
-			c := types.Coordinate{j, i}
+			c := types.Coordinate{X: j, Y: i}
 
 			// First always check if the coordinate is within the map
 			if !s.maps.ValidCoordinate(c) {
@@ -576,14 +1067,14 @@ func (s *State) attackPlayer(mon *monster.Monster) {
 	// If character is invisble chance to miss
 	if s.C.Cond.EffectActive(conditions.Invisiblity) {
 		if rand.Intn(33) < 20 {
-			s.Log(fmt.Sprintf("The %s misses wildly", mName))
+			s.LogAs(Combat, fmt.Sprintf("The %s misses wildly", mName))
 			return
 		}
 	}
 
 	if s.C.Cond.EffectActive(conditions.CharmMonsters) {
 		if rand.Intn(30)+5*mon.Info.Lvl-int(s.C.Stats.Cha) < 30 {
-			s.Log(fmt.Sprintf("The %s is awestruct at your magnificence!", mName))
+			s.LogAs(Combat, fmt.Sprintf("The %s is awestruct at your magnificence!", mName))
 			return
 		}
 	}
@@ -597,12 +1088,9 @@ func (s *State) hitPlayer(mon *monster.Monster) {
 
 	if mon.Info.Attack > 0 {
 		if dmg+s.difficulty+8 > s.C.Stats.Ac || s.C.Stats.Ac <= 0 || rand.Intn(s.C.Stats.Ac) == 0 { // Check for special attack success
-			// TODO check for special attack
-			/*
-				if special() {
-					return
-				}
-			*/
+			if s.specialAttack(mon) {
+				return
+			}
 
 			s.difficulty -= 2
 		}
@@ -610,13 +1098,42 @@ func (s *State) hitPlayer(mon *monster.Monster) {
 
 	// No special attack, deal normal damage
 	if (dmg+s.difficulty) > s.C.Stats.Ac || s.C.Stats.Ac <= 0 || rand.Intn(s.C.Stats.Ac) == 0 {
-		s.Log(fmt.Sprintf("The %v hit you", s.monsterName(mon)))
+		s.LogAs(Combat, fmt.Sprintf("The %v hit you", s.monsterName(mon)))
 		if s.C.Stats.Ac < dmg {
 			s.C.Damage(dmg - s.C.Stats.Ac)
 		}
 	}
 
-	s.Log(fmt.Sprintf("The %s missed", s.monsterName(mon)))
+	s.LogAs(Combat, fmt.Sprintf("The %s missed", s.monsterName(mon)))
+}
+
+// specialAttack performs the monster's special attack on the player. Returns true if the special attack took the monster's turn
+func (s *State) specialAttack(mon *monster.Monster) bool {
+	// Cancellation negates every special attack
+	if s.C.Cond.EffectActive(conditions.Cancellation) {
+		return false
+	}
+
+	mName := s.monsterName(mon)
+	switch mon.Info.Attack {
+	case 2, 3: // fire breath
+		dmg := rand.Intn(15) + 9 - s.C.Stats.Ac
+		if mon.Info.Attack == 3 { // dragons breath hotter
+			dmg = rand.Intn(20) + 26 - s.C.Stats.Ac
+		}
+		s.LogAs(Combat, fmt.Sprintf("The %s breathes fire at you!", mName))
+		if !s.burn(dmg) {
+			s.LogAs(Combat, fmt.Sprintf("The %s's flame doesn't faze you!", mName))
+		}
+		return true
+	case 11: // confusion
+		s.LogAs(Combat, fmt.Sprintf("The %s has confused you", mName))
+		s.C.Cond.Refresh(conditions.Confusion, 10+rand.Intn(10)+1, 0)
+		return true
+	default:
+		// TODO remaining special attacks
+		return false
+	}
 }
 
 // playerAttack deals damage to a monster
@@ -632,7 +1149,7 @@ func (s *State) playerAttack(d types.Direction) {
 		// Deal damage to the monster
 		dead := s.hitMonster(mon)
 		if dead {
-			s.Log(fmt.Sprintf("The %s died", s.monsterName(mon)))
+			s.LogAs(Combat, fmt.Sprintf("The %s died", s.monsterName(mon)))
 			s.maps.RemoveAt(mLoc)                               // remove the mosnter at the location
 			s.maps.CurrentMap()[mLoc.Y][mLoc.X] = mon.Displaced // replace the any items displaced by the monster
 			s.monsterDrop(mLoc, mon)                            // have the monster drop gold/items
@@ -656,7 +1173,7 @@ func (s *State) hitMonster(m *monster.Monster) bool {
 
 	tmp := m.Info.Armor + int(s.C.Stats.Level) + int(s.C.Stats.Dex) + s.C.Stats.Wc/4 - 12
 	if rand.Intn(20) < tmp-s.difficulty || rand.Intn(71) < 5 { // some random chance to hit
-		s.Log(fmt.Sprintf("You hit the %s", s.monsterName(m)))
+		s.LogAs(Combat, fmt.Sprintf("You hit the %s", s.monsterName(m)))
 		dmg := s.hits(1)
 		if dmg < 9999 {
 			dmg = rand.Intn(dmg) + 1
@@ -667,12 +1184,20 @@ func (s *State) hitMonster(m *monster.Monster) bool {
 			"damage":  dmg,
 		}).Debug("damanged monster")
 
-		_, dead = m.Damage(dmg)
+		_, dead = m.Damage(s.playerDamage(dmg))
+
+		// Metal eating and acidic monsters dull the weapon
+		switch m.ID() {
+		case monster.Rustmonster, monster.Disenchantress, monster.Cube:
+			if a, ok := s.C.Wielding().(items.Attributable); ok && a.Attr() > dullLimit {
+				s.C.EnchantWeapon(-1)
+				s.LogAs(Combat, fmt.Sprintf("Your weapon is dulled by the %s", s.monsterName(m)))
+			}
+		}
 	} else {
-		s.Log(fmt.Sprintf("You missed the %s", s.monsterName(m)))
+		s.LogAs(Combat, fmt.Sprintf("You missed the %s", s.monsterName(m)))
 	}
 
-	// TODO handle dulled weapons
 	// TODO handle turning vampires back into bats
 	return dead
 }
@@ -754,16 +1279,23 @@ func (s *State) drop(c types.Coordinate, drop io.Runeable) {
 	}
 
 	// Look for empty adjacent locations to drop
-	for _, a := range s.maps.Adjacent(c) {
-		if _, ok := a.(maps.Empty); ok {
-			s.maps.CurrentMap()[c.Y][c.X] = drop
-			return
-		}
+	if a, ok := s.emptyAdjacent(c); ok {
+		s.maps.CurrentMap()[a.Y][a.X] = drop
 	}
 
 	// NOTE: If we couldn't find a place to drop then nothing gets dropped
 }
 
+// emptyAdjacent returns an empty location next to c. Returns false if there isn't one
+func (s *State) emptyAdjacent(c types.Coordinate) (types.Coordinate, bool) {
+	for _, a := range s.maps.AdjacentCoords(c) {
+		if _, ok := s.maps.At(a).(maps.Empty); ok {
+			return a, true
+		}
+	}
+	return c, false
+}
+
 // Quaff performs a drink potion action
 func (s *State) Quaff(e rune) (func() bool, error) {
 	defer s.update()
@@ -773,10 +1305,11 @@ func (s *State) Quaff(e rune) (func() bool, error) {
 	if err != nil {
 		return nil, err
 	}
+	s.Known.LearnPotion(id)
 
 	// Log all the information that read returned
 	for _, r := range l {
-		s.Log(r)
+		s.LogAs(Item, r)
 	}
 
 	// Special potion cases
@@ -827,6 +1360,9 @@ func (s *State) Quaff(e rune) (func() bool, error) {
 			}
 		})
 	case items.Forgetfulness:
+		s.Known.Forget()
+		s.Known.LearnPotion(id) // the player remembers what they just drank
+		s.maps.ForgetExplored()
 		s.maps.TouchAllInteriorCoordinates(func(obj io.Runeable) {
 			if _, ok := obj.(types.Visibility); ok {
 				obj.(types.Visibility).Visible(false)
@@ -839,7 +1375,7 @@ func (s *State) Quaff(e rune) (func() bool, error) {
 			if i > 0 {
 				i--
 				s.update()
-				s.maps.SetVisible(s.C)
+				s.look()
 				log.Info("sleeping from potion")
 				time.Sleep(time.Second)
 				return true
@@ -856,6 +1392,7 @@ func (s *State) Quaff(e rune) (func() bool, error) {
 func (s *State) projectile(spell *items.Spell, dmg int, msg string, c rune) func(types.Direction) bool {
 	current := s.C.Location()
 	var obj io.Runeable
+	reflected := false
 	return func(d types.Direction) bool {
 		cleanup := func() {
 			if obj != nil {
@@ -871,10 +1408,17 @@ func (s *State) projectile(spell *items.Spell, dmg int, msg string, c rune) func
 		if obj != nil { // replace the object that was displaced
 			s.maps.Swap(current, obj) // TODO replaced objects should probably be visible?
 		}
+		if reflected {
+			d = types.Opposite(d)
+		}
 		current = types.Move(current, d)
 		if s.maps.OutOfBounds(current) { // If the projectile would go off the map, or into a dungeon wall
 			return false
 		}
+		if current == s.C.Location() { // a reflected spell came back to the player
+			s.selfHit(spell, dmg)
+			return false
+		}
 		obj = s.maps.Swap(current, &items.ProjectileSpell{R: c})
 
 		// Object collision handling
@@ -889,7 +1433,7 @@ func (s *State) projectile(spell *items.Spell, dmg int, msg string, c rune) func
 			}
 			// Enough damage to destroy the wall?
 			if (dmg+bonusDmg >= 50+s.difficulty) && s.maps.CurrentLevel() < maps.MaxVolcano && !s.maps.OuterWall(current) {
-				msg = fmt.Sprintf(msg + "  The wall crumbles")
+				msg += "  The wall crumbles"
 				s.maps.Swap(current, &maps.Empty{})
 			} else {
 				cleanup()
@@ -897,13 +1441,16 @@ func (s *State) projectile(spell *items.Spell, dmg int, msg string, c rune) func
 			s.Log(msg)
 			return false
 		case *monster.Monster:
-			s.Log(fmt.Sprintf(msg, s.monsterName(o)))
+			s.LogAs(Combat, fmt.Sprintf(msg, s.monsterName(o)))
 			dealt, dead := s.damageMonster(dmg, o, current)
 			if dead {
 				obj = nil
 			}
 
 			dmg -= dealt
+		case *items.Mirror:
+			s.Log("The spell bounces off the mirror!")
+			reflected = !reflected
 		default:
 			// TODO probably panic here
 			dmg -= (3 + (s.difficulty >> 1)) // reduce power for each space traveled
@@ -919,7 +1466,7 @@ func (s *State) omniDirect(spell *items.Spell, dmg int, msg string) {
 		obj := s.maps.At(c)
 		switch o := obj.(type) {
 		case *monster.Monster:
-			s.Log(fmt.Sprintf(msg, s.monsterName(o)))
+			s.LogAs(Combat, fmt.Sprintf(msg, s.monsterName(o)))
 			s.damageMonster(dmg, o, c)
 		}
 	}
@@ -981,7 +1528,7 @@ func (s *State) directedHit(spell *items.Spell, dmg int, msg string) func(types.
 		switch o := obj.(type) {
 		case *monster.Monster:
 			if msg != "" {
-				s.Log(fmt.Sprintf(msg, s.monsterName(o)))
+				s.LogAs(Combat, fmt.Sprintf(msg, s.monsterName(o)))
 			}
 			s.damageMonster(dmg, o, monLoc)
 		case *items.Mirror:
@@ -1005,16 +1552,48 @@ func (s *State) wieldingLance() bool {
 }
 
 func (s *State) damageMonster(dmg int, m *monster.Monster, loc types.Coordinate) (int, bool) {
-	dealt, dead := m.Damage(dmg)
+	dealt, dead := m.Damage(s.playerDamage(dmg))
 	if dead {
 		// TODO handle gaining exp for killing a monster
 		s.maps.Swap(loc, m.Displaced)
-		s.Log(fmt.Sprintf("The %s died!", s.monsterName(m)))
+		s.LogAs(Combat, fmt.Sprintf("The %s died!", s.monsterName(m)))
 	}
 
 	return dealt, dead
 }
 
+// playerDamage returns the damage the player actually deals. Poisoned players only deal half damage
+func (s *State) playerDamage(dmg int) int {
+	if s.C.Cond.EffectActive(conditions.HalfDamage) {
+		return dmg >> 1
+	}
+	return dmg
+}
+
+// burn deals fire damage to the player, fire resistance protects them completely. Returns false if the player resisted the fire
+func (s *State) burn(dmg int) bool {
+	if s.C.Cond.EffectActive(conditions.FireResistance) {
+		return false
+	}
+	s.C.Damage(dmg)
+	return true
+}
+
+// selfHit is a reflected spell striking the player who cast it. Fireballs burn the player
+func (s *State) selfHit(spell *items.Spell, dmg int) {
+	if spell.Code != "bal" {
+		s.LogAs(Combat, "You are hit by your own magic!")
+		s.C.Damage(dmg)
+		return
+	}
+
+	if !s.burn(dmg) {
+		s.LogAs(Combat, "Your fireball doesn't faze you!")
+		return
+	}
+	s.LogAs(Combat, "You are hit by your own fireball!")
+}
+
 // monsterName returns the name of the monster, handles if the character is blind
 func (s *State) monsterName(m *monster.Monster) string {
 	if s.C.Cond.EffectActive(conditions.Blindness) {
@@ -1023,3 +1602,33 @@ func (s *State) monsterName(m *monster.Monster) string {
 
 	return m.Name()
 }
+
+// PayTaxes pays n gold pieces towards the taxes owed. Paying more than is owed only pays what is owed
+func (s *State) PayTaxes(n int) error {
+	if s.C.Stats.Gold < uint(n) {
+		return ErrNotThatMuch
+	}
+	if n > s.Taxes {
+		n = s.Taxes
+	}
+	s.C.Stats.Gold -= uint(n)
+	s.Taxes -= n
+	return nil
+}
+
+// LatePenalty charges the penalty on taxes left unpaid from a previous game. It's only charged once per game. Returns the penalty charged
+func (s *State) LatePenalty() int {
+	if s.Penalized || s.Taxes <= 0 {
+		return 0
+	}
+	s.Penalized = true
+	p := s.Taxes * latePenalty / 100
+	s.Taxes += p
+	return p
+}
+
+// AssessTaxes adds the taxes owed on the players winnings to any taxes still owed. Unpaid taxes are charged the late penalty again. Returns the total owed
+func (s *State) AssessTaxes(winnings int) int {
+	s.Taxes += s.Taxes*latePenalty/100 + winnings/taxRate
+	return s.Taxes
+}
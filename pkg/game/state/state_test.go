@@ -0,0 +1,296 @@
+package state
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/thorfour/larn/pkg/game/state/conditions"
+	"github.com/thorfour/larn/pkg/game/state/items"
+	"github.com/thorfour/larn/pkg/game/state/monster"
+	"github.com/thorfour/larn/pkg/game/state/types"
+)
+
+// TestConfusion ensures confused players stumble in random directions
+func TestConfusion(t *testing.T) {
+	s := New(0)
+	rand.Seed(1)
+
+	for i := 0; i < 100; i++ {
+		if s.confused(types.Up) != types.Up {
+			t.Fatal("player stumbled without being confused")
+		}
+	}
+
+	s.C.Cond.Add(conditions.Confusion, 100, 0)
+	stumbled := false
+	for i := 0; i < 100; i++ {
+		d := s.confused(types.Up)
+		if d >= types.Here {
+			t.Fatalf("invalid direction %v", d)
+		}
+		stumbled = stumbled || d != types.Up
+	}
+	if !stumbled {
+		t.Error("confused player never stumbled")
+	}
+}
+
+// TestHalfDamage ensures poisoned players only deal half damage
+func TestHalfDamage(t *testing.T) {
+	s := New(0)
+	if s.playerDamage(10) != 10 {
+		t.Error("unexpected damage")
+	}
+
+	s.C.Cond.Add(conditions.HalfDamage, 100, 0)
+	if s.playerDamage(10) != 5 {
+		t.Error("poisoned player dealt full damage")
+	}
+}
+
+// TestSight ensures blind players can't see monsters and invisible stalkers only appear with see invisible
+func TestSight(t *testing.T) {
+	s := New(0)
+	rand.Seed(1)
+
+	c := s.maps.AdjacentCoords(s.C.Location())[0]
+	mon := monster.New(monster.Invisiblestalker)
+	mon.Visible(true)
+	mon.Displaced = s.maps.Swap(c, mon)
+
+	if s.CurrentMap()[c.Y][c.X].Rune() != monster.InvisibleRune {
+		t.Error("invisible stalker was seen")
+	}
+
+	s.C.Cond.Add(conditions.SeeInvisible, 100, 0)
+	if s.CurrentMap()[c.Y][c.X].Rune() != 'I' {
+		t.Error("see invisible didn't reveal the stalker")
+	}
+
+	s.C.Cond.Add(conditions.Blindness, 100, 0)
+	if _, ok := s.CurrentMap()[c.Y][c.X].(monster.Revealed); ok {
+		t.Error("blind player saw a monster")
+	}
+
+	if s.maps.CurrentMap()[c.Y][c.X] != mon {
+		t.Error("the view changed the map")
+	}
+}
+
+// TestBlindView ensures blind players can't see items or what monsters stand on, and only feel items they step on
+func TestBlindView(t *testing.T) {
+	s := New(0)
+	rand.Seed(1)
+
+	coords := s.maps.AdjacentCoords(s.C.Location())
+	c, mc := coords[0], coords[1]
+
+	p := &items.Potion{ID: items.Healing}
+	p.Visible(true)
+	s.maps.Swap(c, p)
+
+	g := &items.Gem{}
+	g.Visible(true)
+	s.maps.Swap(mc, g)
+	mon := monster.New(monster.Bat)
+	mon.Visible(true)
+	mon.Displaced = s.maps.Swap(mc, mon)
+
+	s.C.Cond.Add(conditions.Blindness, 100, 0)
+	for _, at := range []types.Coordinate{c, mc} {
+		if r := s.CurrentMap()[at.Y][at.X].Rune(); r != '.' {
+			t.Errorf("blind player saw %s", string(r))
+		}
+	}
+
+	for d := types.Direction(0); d < types.Here; d++ {
+		if types.Move(s.C.Location(), d) == c {
+			s.Move(d)
+			break
+		}
+	}
+	if s.C.Location() != c {
+		t.Fatal("player didn't move onto the potion")
+	}
+	if msg := s.Messages(Item)[0].Text; msg != "You feel something here" {
+		t.Errorf("blind player logged %q", msg)
+	}
+}
+
+// TestStingy ensures contributions are judged against the gold the player had before contributing
+func TestStingy(t *testing.T) {
+	s := New(0)
+	s.C.Stats.Gold = 1000
+
+	if s.stingy(100) {
+		t.Error("contributing a tenth of the player's gold was stingy")
+	}
+	if !s.stingy(99) {
+		t.Error("contributing less than a tenth of the player's gold wasn't stingy")
+	}
+}
+
+// TestDropMany ensures dropping several items in one action drops every one of them
+func TestDropMany(t *testing.T) {
+	s := New(0)
+	rand.Seed(1)
+
+	var slots []rune
+	for i := 0; i < 2; i++ {
+		r, err := s.C.AddItem(&items.Potion{ID: items.Healing})
+		if err != nil {
+			t.Fatal(err)
+		}
+		slots = append(slots, r)
+	}
+
+	dropped, err := s.Drop(slots...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dropped) != 2 {
+		t.Fatalf("dropped %v items", len(dropped))
+	}
+	for _, r := range slots {
+		if s.C.Item(r) != nil {
+			t.Errorf("item %s is still in the inventory", string(r))
+		}
+	}
+}
+
+// TestFireBreath ensures fire resistance and cancellation protect the player from fire breath
+func TestFireBreath(t *testing.T) {
+	s := New(0)
+	rand.Seed(1)
+	s.C.Stats.Ac = 0
+	hound := monster.New(monster.Hellhound)
+
+	hp := s.C.Stats.Hp
+	s.C.Cond.Add(conditions.FireResistance, 100, 0)
+	if !s.specialAttack(hound) || s.C.Stats.Hp != hp {
+		t.Error("fire resistance didn't protect the player")
+	}
+
+	s.C.Cond.Add(conditions.Cancellation, 100, 0)
+	s.C.Cond.Remove(conditions.FireResistance)
+	if s.specialAttack(hound) || s.C.Stats.Hp != hp {
+		t.Error("cancellation didn't negate the fire breath")
+	}
+
+	s.C.Cond.Remove(conditions.Cancellation)
+	if !s.specialAttack(hound) || s.C.Stats.Hp >= hp {
+		t.Error("fire breath didn't burn the player")
+	}
+}
+
+// TestReflectedFireball ensures fire resistance protects the player from their own fireball reflected off a mirror
+func TestReflectedFireball(t *testing.T) {
+	s := New(0)
+	rand.Seed(1)
+
+	c := s.maps.AdjacentCoords(s.C.Location())[0]
+	mirror := &items.Mirror{}
+	s.maps.Swap(c, mirror)
+	d := types.Direction(0)
+	for types.Move(s.C.Location(), d) != c {
+		d++
+	}
+
+	fireball, _ := items.LookupSpell("bal")
+	cast := func() {
+		for hit := s.projectile(&fireball, 30, "A fireball hits the %s", '*'); hit(d); {
+		}
+	}
+
+	hp := s.C.Stats.Hp
+	s.C.Cond.Add(conditions.FireResistance, 100, 0)
+	cast()
+	if s.C.Stats.Hp != hp {
+		t.Error("fire resistance didn't protect the player from their fireball")
+	}
+
+	s.C.Cond.Remove(conditions.FireResistance)
+	cast()
+	if s.C.Stats.Hp >= hp {
+		t.Error("reflected fireball didn't burn the player")
+	}
+
+	if s.maps.At(c) != mirror {
+		t.Error("fireball destroyed the mirror")
+	}
+}
+
+// TestRegeneration ensures hp and spells come back over time, faster with rings and not at all while poisoned
+func TestRegeneration(t *testing.T) {
+	s := New(0)
+	s.C.Stats.Hp = 1
+	s.C.Stats.MaxHP = 100
+	s.C.Stats.Spells = 0
+
+	turns := func(f func() bool) int {
+		n := 0
+		for ; !f() && n < 10000; n++ {
+			s.regenerate()
+		}
+		return n
+	}
+
+	slow := turns(func() bool { return s.C.Stats.Hp >= 10 })
+	if s.C.Stats.Spells == 0 {
+		t.Error("no spells regained")
+	}
+
+	s.C.Stats.Hp = 1
+	ring := &items.Ring{Type: items.ExtraRegen}
+	ring.PickUp(s.C.Stats)
+	if fast := turns(func() bool { return s.C.Stats.Hp >= 10 }); fast >= slow {
+		t.Errorf("ring of extra regeneration didn't speed up healing, %v >= %v turns", fast, slow)
+	}
+	ring.Drop(s.C.Stats)
+
+	s.C.Stats.Hp = 1
+	s.C.Cond.Add(conditions.HalfDamage, 10000, 0)
+	turns(func() bool { return false })
+	if s.C.Stats.Hp != 1 {
+		t.Error("poisoned player regenerated")
+	}
+}
+
+// TestRest ensures resting passes time and is interrupted when a condition wears off
+func TestRest(t *testing.T) {
+	s := New(0)
+	for _, m := range s.maps.LevelMonsters() { // keep monsters from interrupting
+		m.Visible(false)
+	}
+
+	if n := s.Rest(10); n != 10 || s.timeUsed != 10 {
+		t.Errorf("rested %v turns in %v time", n, s.timeUsed)
+	}
+
+	s.C.Cond.Add(conditions.HasteSelf, 3, 0)
+	if n := s.Rest(10); n != 3 {
+		t.Errorf("rest wasn't interrupted when haste wore off, rested %v turns", n)
+	}
+}
+
+// TestSearch ensures searching eventually finds adjacent hidden traps
+func TestSearch(t *testing.T) {
+	s := New(0)
+	rand.Seed(1)
+
+	c := s.maps.AdjacentCoords(s.C.Location())[0]
+	trap := &items.Trap{TrapType: items.ArrowTrap}
+	s.maps.Swap(c, trap)
+
+	s.look()
+	if trap.Visibility {
+		t.Fatal("hidden trap was seen without searching")
+	}
+
+	for i := 0; i < 100 && !trap.Found; i++ {
+		s.Search()
+	}
+	if !trap.Found || !trap.Visibility {
+		t.Error("search never found the trap")
+	}
+}
@@ -20,6 +20,8 @@ type Stats struct {
 	Cha          uint            // charisma
 	Loc          string          // current location
 	Gold         uint            // current gold being held
+	Regen        int             // extra hp regained each regeneration, from rings
+	Energy       int             // extra spell regeneration, from rings
 	Special      map[int]bool    // Special stats for if the character is holding special items
 	KnownSpells  map[string]bool // Known spells
 }
@@ -0,0 +1,268 @@
+package store
+
+import (
+	"fmt"
+
+	"github.com/thorfour/larn/pkg/game/state/items"
+)
+
+const (
+	restockTime = 1000 // number of turns between restocks
+	baseCha     = 12   // charisma at which the store charges the base price
+	maxDiscount = 30   // largest percent charisma can take off a price
+	maxMarkup   = 20   // largest percent a lack of charisma can add to a price
+)
+
+var (
+	// ErrOutOfStock the requested item is sold out
+	ErrOutOfStock = fmt.Errorf("Sorry, but we are out of that item.")
+	// ErrNotForSale there is no item at the requested index
+	ErrNotForSale = fmt.Errorf("unable to purchase")
+)
+
+// entry is a single catalog item
+type entry struct {
+	index string            // character index
+	price int               // base cost of this item
+	stock int               // number of items the store stocks
+	item  func() items.Item // creates a new instance of the item, nil for filler
+}
+
+var catalog = [][]entry{
+	{ // Page 1
+		// Armor
+		{"a", 20, 3, func() items.Item { return &items.ArmorClass{Type: items.Leather} }},
+		{"b", 100, 2, func() items.Item { return &items.ArmorClass{Type: items.StuddedLeather} }},
+		{"c", 400, 2, func() items.Item { return &items.ArmorClass{Type: items.RingMail} }},
+		{"d", 850, 2, func() items.Item { return &items.ArmorClass{Type: items.ChainMail} }},
+		{"e", 2200, 1, func() items.Item { return &items.ArmorClass{Type: items.SplintMail} }},
+		{"f", 4000, 1, func() items.Item { return &items.ArmorClass{Type: items.PlateMail} }},
+		{"g", 9000, 1, func() items.Item { return &items.ArmorClass{Type: items.PlateArmor} }},
+		{"h", 26000, 1, func() items.Item { return &items.ArmorClass{Type: items.StainlessPlateArmor} }},
+		// Weapons
+		{"i", 1500, 1, func() items.Item { return &items.Shield{} }},
+		{"j", 20, 3, func() items.Item { return &items.WeaponClass{Type: items.Dagger} }},
+		{"k", 200, 3, func() items.Item { return &items.WeaponClass{Type: items.Spear} }},
+		{"l", 800, 2, func() items.Item { return &items.WeaponClass{Type: items.Flail} }},
+		{"m", 1500, 2, func() items.Item { return &items.WeaponClass{Type: items.BattleAxe} }},
+		{"n", 4500, 2, func() items.Item { return &items.WeaponClass{Type: items.LongSword} }},
+		{"o", 10000, 2, func() items.Item { return &items.WeaponClass{Type: items.TwoHandedSword} }},
+		{"p", 50000, 1, func() items.Item { return &items.WeaponClass{Type: items.SunSword} }},
+		{"q", 165000, 1, func() items.Item { return &items.WeaponClass{Type: items.LanceOfDeath} }},
+		// Filler
+		{"r", -1, 0, nil},
+		{"s", -1, 0, nil},
+		// Rings
+		{"t", 1500, 1, func() items.Item { return &items.Ring{Type: items.Protection} }},
+		{"u", 850, 1, func() items.Item { return &items.Ring{Type: items.Strength} }},
+		{"v", 1200, 1, func() items.Item { return &items.Ring{Type: items.Dexterity} }},
+		{"w", 1200, 1, func() items.Item { return &items.Ring{Type: items.Clever} }},
+		{"x", 1800, 1, func() items.Item { return &items.Ring{Type: items.Energy} }},
+		{"y", 1250, 1, func() items.Item { return &items.Ring{Type: items.Damage} }},
+		{"z", 2200, 1, func() items.Item { return &items.Ring{Type: items.Regen} }},
+	},
+	{ // Page 2
+		{"a", 10000, 1, func() items.Item { return &items.Ring{Type: items.ExtraRegen} }},
+		{"b", 2800, 1, func() items.Item { return &items.Belt{} }},
+		{"c", 4000, 1, func() items.Item { return &items.Special{Type: items.Amulet} }},
+		// Filler
+		{"d", -1, 0, nil},
+		{"e", -1, 0, nil},
+		{"f", -1, 0, nil},
+		{"g", -1, 0, nil},
+		// Items
+		{"h", 5900, 1, func() items.Item { return &items.Chest{} }},
+		{"i", 2000, 1, func() items.Item { return &items.Book{} }},
+		{"j", 100, 3, func() items.Item { return &items.Cookie{} }},
+		// Potions
+		{"k", 200, 6, func() items.Item { return &items.Potion{ID: items.Sleep, Store: true} }},
+		{"l", 900, 5, func() items.Item { return &items.Potion{ID: items.Healing, Store: true} }},
+		{"m", 5200, 1, func() items.Item { return &items.Potion{ID: items.RaiseLevel, Store: true} }},
+		{"n", 1000, 2, func() items.Item { return &items.Potion{ID: items.IncreaseAbility, Store: true} }},
+		{"o", 500, 2, func() items.Item { return &items.Potion{ID: items.GainWisdom, Store: true} }},
+		{"p", 1500, 2, func() items.Item { return &items.Potion{ID: items.GainStrength, Store: true} }},
+		{"q", 700, 1, func() items.Item { return &items.Potion{ID: items.IncreaseCharisma, Store: true} }},
+		{"r", 300, 7, func() items.Item { return &items.Potion{ID: items.Dizziness, Store: true} }},
+		{"s", 2000, 1, func() items.Item { return &items.Potion{ID: items.Learning, Store: true} }},
+		{"t", 500, 1, func() items.Item { return &items.Potion{ID: items.ObjectDetection, Store: true} }},
+		{"u", 800, 1, func() items.Item { return &items.Potion{ID: items.MonsterDetection, Store: true} }},
+		{"v", 300, 3, func() items.Item { return &items.Potion{ID: items.Forgetfulness, Store: true} }},
+		{"w", 200, 5, func() items.Item { return &items.Potion{ID: items.Water, Store: true} }},
+		{"x", 400, 3, func() items.Item { return &items.Potion{ID: items.Blindness, Store: true} }},
+		{"y", 350, 2, func() items.Item { return &items.Potion{ID: items.Confusion, Store: true} }},
+		{"z", 5200, 1, func() items.Item { return &items.Potion{ID: items.Heroism, Store: true} }},
+	},
+	{ // Page 3
+		// Potions
+		{"a", 900, 2, func() items.Item { return &items.Potion{ID: items.Sturdiness, Store: true} }},
+		{"b", 2000, 2, func() items.Item { return &items.Potion{ID: items.GiantStrength, Store: true} }},
+		{"c", 2200, 4, func() items.Item { return &items.Potion{ID: items.FireResistance, Store: true} }},
+		{"d", 800, 6, func() items.Item { return &items.Potion{ID: items.TreasureFinding, Store: true} }},
+		{"e", 3700, 3, func() items.Item { return &items.Potion{ID: items.InstantHealing, Store: true} }},
+		{"f", 500, 1, func() items.Item { return &items.Potion{ID: items.Poison, Store: true} }},
+		{"g", 1500, 3, func() items.Item { return &items.Potion{ID: items.SeeInvisible, Store: true} }},
+		// Scrolls
+		{"h", 1500, 2, func() items.Item { return &items.Scroll{ID: items.EnchantArmor, Store: true} }},
+		{"i", 1250, 2, func() items.Item { return &items.Scroll{ID: items.EnchantWeapon, Store: true} }},
+		{"j", 600, 4, func() items.Item { return &items.Scroll{ID: items.Englightenment, Store: true} }},
+		{"k", 100, 4, func() items.Item { return &items.Scroll{ID: items.Paper, Store: true} }},
+		{"l", 1000, 3, func() items.Item { return &items.Scroll{ID: items.CreateMonster, Store: true} }},
+		{"m", 2000, 2, func() items.Item { return &items.Scroll{ID: items.CreateItem, Store: true} }},
+		{"n", 1100, 1, func() items.Item { return &items.Scroll{ID: items.Aggravate, Store: true} }},
+		{"o", 5000, 2, func() items.Item { return &items.Scroll{ID: items.TimeWarp, Store: true} }},
+		{"p", 2000, 2, func() items.Item { return &items.Scroll{ID: items.Teleportation, Store: true} }},
+		{"q", 2500, 4, func() items.Item { return &items.Scroll{ID: items.ExpandedAwareness, Store: true} }},
+		{"r", 200, 5, func() items.Item { return &items.Scroll{ID: items.HasteMonster, Store: true} }},
+		{"s", 300, 3, func() items.Item { return &items.Scroll{ID: items.HealMonster, Store: true} }},
+		{"t", 3400, 1, func() items.Item { return &items.Scroll{ID: items.SpiritProtection, Store: true} }},
+		{"u", 3400, 1, func() items.Item { return &items.Scroll{ID: items.UndeadProtection, Store: true} }},
+		{"v", 3000, 2, func() items.Item { return &items.Scroll{ID: items.Stealth, Store: true} }},
+		{"w", 4000, 2, func() items.Item { return &items.Scroll{ID: items.MagicMapping, Store: true} }},
+		{"x", 5000, 2, func() items.Item { return &items.Scroll{ID: items.HoldMonster, Store: true} }},
+		{"y", 10000, 1, func() items.Item { return &items.Scroll{ID: items.GemPerfection, Store: true} }},
+		{"z", 5000, 1, func() items.Item { return &items.Scroll{ID: items.SpellExtension, Store: true} }},
+	},
+	{ // Page 4
+		{"a", 3400, 2, func() items.Item { return &items.Scroll{ID: items.Identify, Store: true} }},
+		{"b", 2200, 3, func() items.Item { return &items.Scroll{ID: items.RemoveCurse, Store: true} }},
+		{"c", -1, 0, nil},
+		{"d", 6100, 1, func() items.Item { return &items.Scroll{ID: items.Pulverization, Store: true} }},
+	},
+}
+
+// Listing is an item for sale on a page of the store
+type Listing struct {
+	Index string     // character index
+	Price int        // cost of the item to the buyer
+	Stock int        // number of items in stock
+	Item  items.Item // a new instance of the item
+}
+
+// Store is the per-game stock of the DND store
+type Store struct {
+	Stock       [][]int // number of each item in stock, indexed by page and catalog position
+	LastRestock uint    // game time of the last restock
+}
+
+// New returns a fully stocked store
+func New() *Store {
+	s := new(Store)
+	s.Stock = make([][]int, len(catalog))
+	for p := range catalog {
+		s.Stock[p] = make([]int, len(catalog[p]))
+		for i, e := range catalog[p] {
+			s.Stock[p][i] = e.stock
+		}
+	}
+	return s
+}
+
+// Page returns the listings on page n, priced for a buyer with the given charisma
+func (s *Store) Page(n int, cha uint) []Listing {
+	p := n % len(catalog)
+	var l []Listing
+	for i, e := range catalog[p] {
+		if e.item == nil {
+			l = append(l, Listing{Index: e.index})
+			continue
+		}
+		item := e.item()
+		l = append(l, Listing{
+			Index: e.index,
+			Price: Price(Value(item, nil), cha),
+			Stock: s.Stock[p][i],
+			Item:  item,
+		})
+	}
+	return l
+}
+
+// Listing returns the listing at index r of page n. Returns an error if the item isn't available
+func (s *Store) Listing(n int, r rune, cha uint) (Listing, error) {
+	for _, l := range s.Page(n, cha) {
+		if l.Index != string(r) {
+			continue
+		}
+		if l.Item == nil {
+			return l, ErrNotForSale
+		}
+		if l.Stock == 0 {
+			return l, ErrOutOfStock
+		}
+		return l, nil
+	}
+	return Listing{}, ErrNotForSale
+}
+
+// Take removes one of the items at index r of page n from stock
+func (s *Store) Take(n int, r rune) {
+	p := n % len(catalog)
+	for i, e := range catalog[p] {
+		if e.index == string(r) && s.Stock[p][i] > 0 {
+			s.Stock[p][i]--
+			return
+		}
+	}
+}
+
+// Restock adds one of each sold out item back into stock, if enough time has passed since the last restock
+func (s *Store) Restock(now uint) {
+	if now-s.LastRestock < restockTime {
+		return
+	}
+	s.LastRestock = now
+
+	for p := range catalog {
+		for i, e := range catalog[p] {
+			if s.Stock[p][i] < e.stock {
+				s.Stock[p][i]++
+			}
+		}
+	}
+}
+
+// Price adjusts a base price by the buyers charisma. Every point of charisma above the base takes 2% off, every point below adds 2%
+func Price(base int, cha uint) int {
+	pct := 2 * (int(cha) - baseCha)
+	switch {
+	case pct > maxDiscount:
+		pct = maxDiscount
+	case pct < -maxMarkup:
+		pct = -maxMarkup
+	}
+	return base * (100 - pct) / 100
+}
+
+// BasePrice returns the catalog price of the item, 0 if the store doesn't sell it
+func BasePrice(t items.Item) int {
+	for p := range catalog {
+		for _, e := range catalog[p] {
+			if e.item != nil && sameKind(e.item(), t) {
+				return e.price
+			}
+		}
+	}
+	return 0
+}
+
+// sameKind returns true if both items are the same kind of item, regardless of their attributes or curses
+func sameKind(a, b items.Item) bool {
+	switch t := a.(type) {
+	case *items.WeaponClass:
+		w, ok := b.(*items.WeaponClass)
+		return ok && w.Type == t.Type
+	case *items.ArmorClass:
+		w, ok := b.(*items.ArmorClass)
+		return ok && w.Type == t.Type
+	case *items.Ring:
+		w, ok := b.(*items.Ring)
+		return ok && w.Type == t.Type
+	case *items.Shield:
+		_, ok := b.(*items.Shield)
+		return ok
+	case *items.Belt:
+		_, ok := b.(*items.Belt)
+		return ok
+	}
+	return a.String(nil) == b.String(nil) // Compare the remaining items based on their display name
+}
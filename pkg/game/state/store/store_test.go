@@ -0,0 +1,104 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/thorfour/larn/pkg/game/state/items"
+)
+
+func TestStoreInstances(t *testing.T) {
+	s := New()
+
+	l0, err := s.Listing(0, 'a', baseCha)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.Take(0, 'a')
+
+	l1, err := s.Listing(0, 'a', baseCha)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l0.Item == l1.Item {
+		t.Error("store handed out the same item twice")
+	}
+
+	if l1.Stock != l0.Stock-1 {
+		t.Errorf("unexpected stock %v", l1.Stock)
+	}
+}
+
+func TestRestock(t *testing.T) {
+	s := New()
+	s.Take(0, 'h') // stainless plate armor, only 1 in stock
+
+	if _, err := s.Listing(0, 'h', baseCha); err != ErrOutOfStock {
+		t.Error("expected item to be out of stock")
+	}
+
+	s.Restock(restockTime - 1)
+	if _, err := s.Listing(0, 'h', baseCha); err != ErrOutOfStock {
+		t.Error("restocked too early")
+	}
+
+	s.Restock(restockTime)
+	if _, err := s.Listing(0, 'h', baseCha); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPrice(t *testing.T) {
+	if Price(1000, baseCha) != 1000 {
+		t.Error("base charisma should pay the base price")
+	}
+
+	if Price(1000, baseCha+3) != 940 {
+		t.Errorf("unexpected price %v", Price(1000, baseCha+3))
+	}
+
+	if Price(1000, 100) != 700 || Price(1000, 0) != 1200 {
+		t.Error("price adjustment wasn't capped")
+	}
+}
+
+func TestBasePrice(t *testing.T) {
+	w := &items.WeaponClass{Type: items.Dagger}
+	w.ResetAttr(3)
+	w.Curse()
+
+	if BasePrice(w) != 20 {
+		t.Errorf("unexpected base price %v", BasePrice(w))
+	}
+}
+
+func TestOffer(t *testing.T) {
+	k := items.NewKnowledge()
+
+	w := &items.WeaponClass{Type: items.LongSword}
+	if Offer(w, k) != 0 {
+		t.Error("unidentified items shouldn't be bought")
+	}
+
+	k.Identify(w)
+	if Offer(w, k) != 900 {
+		t.Errorf("unexpected offer %v", Offer(w, k))
+	}
+
+	w.Curse()
+	if Offer(w, k) != 450 {
+		t.Errorf("unexpected offer for damaged goods %v", Offer(w, k))
+	}
+
+	e := &items.WeaponClass{Type: items.LongSword}
+	e.ResetAttr(2)
+	k.Identify(e)
+	if Value(e, k) <= Value(w, k) {
+		t.Error("enchantment didn't add value")
+	}
+
+	g := &items.Gem{Stone: items.Ruby, Quality: 5}
+	if Offer(g, k) != g.Appraisal() {
+		t.Error("trading post and bank pay different rates for a gem")
+	}
+}
@@ -0,0 +1,59 @@
+package store
+
+import "github.com/thorfour/larn/pkg/game/state/items"
+
+const (
+	maxValue   = 5000000 // enchantments stop adding value past this point
+	offerPct   = 20      // percent of the new value the trading post pays
+	damagedPct = 10      // percent of the new value the trading post pays for damaged goods
+)
+
+// Value returns what a new copy of the item would be worth, as far as the knowledge k can tell.
+// Unknown enchantments are valued as if the item had none. A nil k values everything
+func Value(i items.Item, k *items.Knowledge) int {
+	if g, ok := i.(*items.Gem); ok {
+		return g.Appraisal()
+	}
+
+	v := BasePrice(i)
+	a, ok := i.(items.Attributable)
+	if !ok || !k.KnownBonus(a) {
+		return v
+	}
+
+	// Every known level of enchantment adds to the value
+	for n := a.Attr(); n > 0 && v < maxValue; n-- {
+		v = 14 * (670 + v) / 10
+	}
+	return v
+}
+
+// Damaged returns true if the item is known to be damaged. Dulled and cursed items are damaged
+func Damaged(i items.Item, k *items.Knowledge) bool {
+	a, ok := i.(items.Attributable)
+	if !ok {
+		return false
+	}
+
+	if c, ok := i.(items.Cursable); ok && c.IsCursed() && k.KnownCurse(a) {
+		return true
+	}
+	return a.Attr() < 0 && k.KnownBonus(a)
+}
+
+// Offer returns what the trading post is willing to pay for the item. 0 if the item can't be sold.
+// Gems are bought at their full appraisal, the same rate the bank pays
+func Offer(i items.Item, k *items.Knowledge) int {
+	if !k.Identified(i) {
+		return 0
+	}
+
+	if g, ok := i.(*items.Gem); ok {
+		return g.Appraisal()
+	}
+
+	if Damaged(i, k) {
+		return Value(i, k) * damagedPct / 100
+	}
+	return Value(i, k) * offerPct / 100
+}
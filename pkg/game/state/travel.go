@@ -0,0 +1,222 @@
+package state
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/thorfour/larn/pkg/game/state/conditions"
+	"github.com/thorfour/larn/pkg/game/state/items"
+	"github.com/thorfour/larn/pkg/game/state/maps"
+	"github.com/thorfour/larn/pkg/game/state/monster"
+	"github.com/thorfour/larn/pkg/game/state/types"
+	"github.com/thorfour/larn/pkg/io"
+)
+
+var (
+	// ErrNoPath the player can't find a way to the travel target
+	ErrNoPath = fmt.Errorf("You can't find a way there")
+	// ErrExplored there is nowhere left on the level to explore
+	ErrExplored = fmt.Errorf("There's nothing left to explore here")
+	// ErrBlindExplore the player can't explore what they can't see
+	ErrBlindExplore = fmt.Errorf("You can't explore while you're blind")
+)
+
+// Target is a place the player can travel to
+type Target struct {
+	Name  string
+	Coord types.Coordinate
+}
+
+// Run moves the player in a direction until something interesting happens: a monster comes into view, the player
+// is hit, the corridor forks or opens up, or the player steps onto an item, stairs or an entrance. Returns the number of steps taken
+func (s *State) Run(d types.Direction) int {
+	seen := s.nearbyMonsters()
+	lvl := s.maps.CurrentLevel()
+	sides := s.openSides(d)
+	n := 0
+	for {
+		next := types.Move(s.C.Location(), d)
+		if !s.maps.ValidCoordinate(next) || (n > 0 && !s.passable(next)) { // stop in front of monsters instead of attacking them
+			return n
+		}
+		empty := isEmpty(s.maps.At(next))
+
+		hp := s.C.Stats.Hp
+		if !s.Move(d) {
+			return n
+		}
+		n++
+
+		switch {
+		case s.maps.CurrentLevel() != lvl, !empty, s.interrupted(hp, seen):
+			return n
+		}
+
+		// Stop where the corridor forks or opens into a room
+		if o := s.openSides(d); o != sides {
+			return n
+		}
+	}
+}
+
+// openSides returns the number of displaceable tiles on either side of the player, relative to the direction of travel
+func (s *State) openSides(d types.Direction) int {
+	var sides []types.Direction
+	switch d {
+	case types.Up, types.Down:
+		sides = []types.Direction{types.Left, types.Right}
+	case types.Left, types.Right:
+		sides = []types.Direction{types.Up, types.Down}
+	default: // diagonal runs don't follow corridors
+		return 0
+	}
+
+	n := 0
+	for _, side := range sides {
+		if s.passable(types.Move(s.C.Location(), side)) {
+			n++
+		}
+	}
+	return n
+}
+
+// isEmpty returns true if the object is an empty floor tile
+func isEmpty(obj io.Runeable) bool {
+	switch obj.(type) {
+	case maps.Empty, monster.Empty:
+		return true
+	}
+	return false
+}
+
+// passable returns true if the player can walk onto the coordinate
+func (s *State) passable(c types.Coordinate) bool {
+	if !s.maps.ValidCoordinate(c) {
+		return false
+	}
+	_, ok := s.maps.At(c).(maps.Displaceable)
+	return ok
+}
+
+// TravelTargets returns the places on the current level the player knows about and could travel to, closest first
+func (s *State) TravelTargets() []Target {
+	var targets []Target
+	for y, row := range s.maps.CurrentMap() {
+		for x, obj := range row {
+			if obj.Rune() == ' ' { // the player hasn't seen it
+				continue
+			}
+			if name := s.targetName(obj); name != "" {
+				targets = append(targets, Target{Name: name, Coord: types.Coordinate{X: x, Y: y}})
+			}
+		}
+	}
+
+	loc := types.Coordinate(s.C.Location())
+	sort.SliceStable(targets, func(i, j int) bool {
+		return s.maps.Distance(loc, targets[i].Coord) < s.maps.Distance(loc, targets[j].Coord)
+	})
+	return targets
+}
+
+// targetName returns the name of a travel target, or an empty string if the object isn't worth travelling to
+func (s *State) targetName(obj io.Runeable) string {
+	switch t := obj.(type) {
+	case *maps.Stairs:
+		return t.Name()
+	case maps.Entrance:
+		return t.Name()
+	case *items.Trap:
+		return ""
+	case *items.GoldPile:
+		return "gold"
+	case items.Item:
+		return t.String(s.Known)
+	default:
+		return ""
+	}
+}
+
+// Travel walks the player to the target coordinate. Travel is interrupted the same way running is. Returns the number of steps taken
+func (s *State) Travel(to types.Coordinate) (int, error) {
+	path := s.maps.Path(s.C.Location(), to)
+	if path == nil {
+		return 0, ErrNoPath
+	}
+
+	seen := s.nearbyMonsters()
+	lvl := s.maps.CurrentLevel()
+	for n, d := range path {
+		if !s.passable(types.Move(s.C.Location(), d)) { // something moved into the way
+			return n, nil
+		}
+
+		hp := s.C.Stats.Hp
+		if !s.Move(d) || s.maps.CurrentLevel() != lvl || s.interrupted(hp, seen) {
+			return n + 1, nil
+		}
+	}
+	return len(path), nil
+}
+
+// Explore walks the player toward the nearest unexplored tile until the level is explored or something interesting happens:
+// a monster comes into view, the player is hit, or an item or fixture is found. Returns the number of steps taken
+func (s *State) Explore() (int, error) {
+	seen := s.nearbyMonsters()
+	found := s.visibleObjects()
+	lvl := s.maps.CurrentLevel()
+	for n := 0; n < maxExplore; n++ {
+		if s.C.Cond.EffectActive(conditions.Blindness) { // blind players don't uncover anything, they'd wander forever
+			if n == 0 {
+				return 0, ErrBlindExplore
+			}
+			s.Log(ErrBlindExplore.Error())
+			return n, nil
+		}
+
+		path := s.maps.PathToUnexplored(s.C.Location())
+		if path == nil {
+			if n == 0 {
+				return 0, ErrExplored
+			}
+			s.Log(ErrExplored.Error())
+			return n, nil
+		}
+
+		if !s.passable(types.Move(s.C.Location(), path[0])) { // something moved into the way
+			return n, nil
+		}
+
+		hp := s.C.Stats.Hp
+		if !s.Move(path[0]) || s.maps.CurrentLevel() != lvl || s.interrupted(hp, seen) {
+			return n + 1, nil
+		}
+
+		if !isEmpty(s.C.Displaced) { // stepped onto something, Move has already told the player about it
+			return n + 1, nil
+		}
+
+		if s.visibleObjects() > found {
+			s.Log("You see something interesting")
+			return n + 1, nil
+		}
+	}
+	return maxExplore, nil
+}
+
+// visibleObjects returns the number of items and fixtures the player can see on the level
+func (s *State) visibleObjects() int {
+	n := 0
+	for _, row := range s.maps.CurrentMap() {
+		for _, obj := range row {
+			if obj.Rune() == ' ' {
+				continue
+			}
+			switch obj.(type) {
+			case items.Item, maps.Loggable, *maps.Stairs, *items.Trap:
+				n++
+			}
+		}
+	}
+	return n
+}
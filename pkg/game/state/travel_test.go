@@ -0,0 +1,91 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/thorfour/larn/pkg/game/state/conditions"
+	"github.com/thorfour/larn/pkg/game/state/maps"
+	"github.com/thorfour/larn/pkg/game/state/monster"
+	"github.com/thorfour/larn/pkg/game/state/types"
+)
+
+// TestTravel ensures the player can travel to the dungeon entrance on the home level
+func TestTravel(t *testing.T) {
+	s := New(0)
+
+	var entrance *Target
+	for _, target := range s.TravelTargets() {
+		if target.Name == "dungeon entrance" {
+			entrance = &target
+			break
+		}
+	}
+	if entrance == nil {
+		t.Fatal("dungeon entrance isn't a travel target")
+	}
+
+	if _, err := s.Travel(entrance.Coord); err != nil {
+		t.Fatal(err)
+	}
+
+	if types.Coordinate(s.C.Location()) != entrance.Coord {
+		t.Errorf("player stopped at %v instead of %v", s.C.Location(), entrance.Coord)
+	}
+
+	if _, ok := s.C.Displaced.(maps.Entrance); !ok {
+		t.Error("player isn't standing on the entrance")
+	}
+}
+
+// TestRun ensures running only stops when there's a reason to
+func TestRun(t *testing.T) {
+	s := New(0)
+	s.Run(types.Right)
+
+	c := types.Coordinate(s.C.Location())
+	c.X++
+	if s.passable(c) && isEmpty(s.maps.At(c)) && isEmpty(s.C.Displaced) && s.openSides(types.Right) == 2 {
+		t.Errorf("player stopped running at %v for no reason", s.C.Location())
+	}
+}
+
+// TestExplore ensures exploring eventually sees every reachable tile
+func TestExplore(t *testing.T) {
+	s := New(0)
+	s.maps.EnterLevel(s.C, 1)
+	for _, row := range s.maps.CurrentMap() { // keep monsters from interrupting
+		for x, obj := range row {
+			if m, ok := obj.(*monster.Monster); ok {
+				row[x] = m.Displaced
+			}
+		}
+	}
+
+	var err error
+	for i := 0; i < 1000 && err == nil; i++ {
+		_, err = s.Explore()
+	}
+
+	if err != ErrExplored {
+		t.Fatalf("level wasn't explored: %v", err)
+	}
+
+	if s.maps.PathToUnexplored(s.C.Location()) != nil {
+		t.Error("reachable tiles left unexplored")
+	}
+}
+
+// TestExploreBlind ensures blind players don't wander the level trying to explore it
+func TestExploreBlind(t *testing.T) {
+	s := New(0)
+	s.maps.EnterLevel(s.C, 1)
+	s.C.Cond.Add(conditions.Blindness, 100, 0)
+
+	turns := s.timeUsed
+	if n, err := s.Explore(); err != ErrBlindExplore || n != 0 {
+		t.Errorf("blind player explored %v steps: %v", n, err)
+	}
+	if s.timeUsed != turns {
+		t.Error("refusing to explore used up turns")
+	}
+}
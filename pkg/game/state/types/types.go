@@ -64,6 +64,30 @@ func Move(c Coordinate, d Direction) Coordinate {
 	return c
 }
 
+// Opposite returns the direction that points back the way d came
+func Opposite(d Direction) Direction {
+	switch d {
+	case Up:
+		return Down
+	case Down:
+		return Up
+	case Left:
+		return Right
+	case Right:
+		return Left
+	case UpLeft:
+		return DownRight
+	case UpRight:
+		return DownLeft
+	case DownLeft:
+		return UpRight
+	case DownRight:
+		return UpLeft
+	}
+
+	return d
+}
+
 // Visibility set
 type Visibility interface {
 	Visible(bool)
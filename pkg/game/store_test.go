@@ -3,14 +3,16 @@ package game
 import (
 	"fmt"
 	"testing"
+
+	"github.com/thorfour/larn/pkg/game/state/store"
 )
 
 var divider = `---------------------------------------------------------------`
 
 func TestRender(t *testing.T) {
-	fmt.Println(dndstorepage(0, 100))
+	fmt.Println(dndstorepage(store.New().Page(0, 12), 100))
 	fmt.Println(divider)
 	fmt.Println(bankPage(100, nil))
 	fmt.Println(divider)
-	fmt.Println(lrsPage(100))
+	fmt.Println(lrsPage(100, 100))
 }
@@ -8,6 +8,7 @@ import (
 
 	termbox "github.com/nsf/termbox-go"
 	"github.com/thorfour/larn/pkg/game/state/items"
+	"github.com/thorfour/larn/pkg/game/state/store"
 )
 
 // MaxDisplay the max number of inventory items to display at once
@@ -33,7 +34,6 @@ func tradingPost(inv []string) string {
 		if i >= MaxDisplay { // only display up to max
 			break
 		}
-		// TODO don't display items that aren't identified
 		if i%2 == 0 {
 			fmt.Fprintf(w, "  %s\t\t\t\t", t)
 		} else {
@@ -54,10 +54,10 @@ func tradingPost(inv []string) string {
 
 // tradingPostHandler input handler for the trading post
 func (g *Game) tradingPostHandler() func(termbox.Event) {
-	g.renderSplash(tradingPost(g.currentState.C.Inventory()))
+	g.renderSplash(tradingPost(g.sellableInventory()))
 	return func(e termbox.Event) {
-		switch e.Key {
-		case termbox.KeyEsc: // Exit
+		switch g.keys.action(e) {
+		case Cancel: // Exit
 			g.inputHandler = g.defaultHandler
 			g.render(display(g.currentState))
 		default:
@@ -119,6 +119,13 @@ func (g *Game) tradingPostHandler() func(termbox.Event) {
 	}
 }
 
+// sellableInventory returns the inventory items the trading post is willing to buy
+func (g *Game) sellableInventory() []string {
+	return g.currentState.C.InventoryIf(g.currentState.Known, func(i items.Item) bool {
+		return store.Offer(i, g.currentState.Known) > 0
+	})
+}
+
 func (g *Game) validateItemSale(r rune) error {
 
 	//  Check if they have the item
@@ -128,16 +135,13 @@ func (g *Game) validateItemSale(r rune) error {
 	}
 
 	//  check if the item is identified
-	known := true
-	switch t := i.(type) {
-	case *items.Potion:
-		known = items.KnownPotion(t.ID)
-	case *items.Scroll:
-		known = items.KnownScroll(t.ID)
+	if !g.currentState.Known.Identified(i) {
+		return fmt.Errorf("\n\n  Sorry, we can't accept unidentified objects")
 	}
 
-	if !known {
-		return fmt.Errorf("\n\n  Sorry, we can't accept unidentified objects")
+	// check if the item is worth anything
+	if store.Offer(i, g.currentState.Known) == 0 {
+		return fmt.Errorf("\n\n  Sorry, we have no use for that")
 	}
 
 	return nil
@@ -145,41 +149,24 @@ func (g *Game) validateItemSale(r rune) error {
 
 func (g *Game) handleSellingInv(r rune) {
 	if err := g.validateItemSale(r); err != nil {
-		g.renderSplash(tradingPost(g.currentState.C.Inventory()) + err.Error())
+		g.renderSplash(tradingPost(g.sellableInventory()) + err.Error())
 		time.Sleep(time.Millisecond * 700)
-		g.renderSplash(tradingPost(g.currentState.C.Inventory()))
+		g.renderSplash(tradingPost(g.sellableInventory()))
 		return
 	}
 
 	// value the item
-	val := 0
-	i := g.currentState.C.Item(r)
-	dndPrice := g.DNDStoreLookup(i)
-	switch t := i.(type) {
-	// TODO handle eye of larn case
-	case *items.Gem:
-		val = 20 * t.Value
-	case *items.Scroll:
-		val = 2 * dndPrice
-	case *items.Potion:
-		val = 2 * dndPrice
-	case items.Attributable:
-		val = dndPrice
-		// Add value if item has positive modifiers
-		if a := t.Attr(); a >= 0 {
-			val *= 2
-			for ; a > 0 && val < 500000; a-- { // NOTE: This is the goofiest modifier
-				val = (14 * (67 + val) / 10)
-			}
-		}
-	default:
-		val = dndPrice
-	}
+	val := store.Offer(g.currentState.C.Item(r), g.currentState.Known)
 
-	g.renderSplash(tradingPost(g.currentState.C.Inventory()) + fmt.Sprintf("\n\n  Item (%s) is worth %d gold pieces to us. Do you want to sell it?", string(r), val))
+	g.renderSplash(tradingPost(g.sellableInventory()) + g.offerLine(r, val))
 	g.inputHandler = g.sellConfirmationHandler(r, val)
 }
 
+// offerLine is the trading post's offer for the item at r
+func (g *Game) offerLine(r rune, val int) string {
+	return fmt.Sprintf("\n\n  %s) %s is worth %d gold pieces to us. Do you want to sell it?", string(r), g.currentState.C.Item(r).String(g.currentState.Known), val)
+}
+
 // sellConfirmationHandler waits for confirmation to sell an item
 func (g *Game) sellConfirmationHandler(r rune, val int) func(termbox.Event) {
 	return func(e termbox.Event) {
@@ -187,32 +174,22 @@ func (g *Game) sellConfirmationHandler(r rune, val int) func(termbox.Event) {
 		case 'y':
 			fallthrough
 		case 'Y': // Sale
-			g.renderSplash(tradingPost(g.currentState.C.Inventory()) + fmt.Sprintf("\n\n  Item (%s) is worth %d gold pieces to us. Do you want to sell it?", string(r), val) + "\n\n  yes")
-			g.currentState.C.DropItem(r)             // Remove the item from players inventory
-			g.currentState.C.Stats.Gold += uint(val) // Add the value of the sale to the users gold
+			g.renderSplash(tradingPost(g.sellableInventory()) + g.offerLine(r, val) + "\n\n  yes")
+			if _, err := g.currentState.C.DropItem(r); err != nil { // Remove the item from players inventory
+				g.renderSplash(tradingPost(g.sellableInventory()) + "\n\n  " + err.Error())
+			} else {
+				g.currentState.C.Stats.Gold += uint(val) // Add the value of the sale to the users gold
+			}
 			time.Sleep(time.Millisecond * 700)
-			g.renderSplash(tradingPost(g.currentState.C.Inventory()))
+			g.renderSplash(tradingPost(g.sellableInventory()))
 			g.inputHandler = g.tradingPostHandler()
 			return
 		default: // No sale
-			g.renderSplash(tradingPost(g.currentState.C.Inventory()) + fmt.Sprintf("\n\n  Item (%s) is worth %d gold pieces to us. Do you want to sell it?", string(r), val) + "\n\n  no thanks.")
+			g.renderSplash(tradingPost(g.sellableInventory()) + g.offerLine(r, val) + "\n\n  no thanks.")
 			time.Sleep(time.Millisecond * 700)
-			g.renderSplash(tradingPost(g.currentState.C.Inventory()))
+			g.renderSplash(tradingPost(g.sellableInventory()))
 			g.inputHandler = g.tradingPostHandler()
 			return
 		}
 	}
 }
-
-// DNDStoreLookup reutns the price of an item in the DND store
-func (g *Game) DNDStoreLookup(t items.Item) int {
-	for i := range store {
-		for _, sale := range store[i] {
-			if sale.String() == t.String() { // Compare the items based on their display name TODO this is kinda gross and would be nice to be replaced by an actual type comparison
-				return sale.price / 10 // (reduce all sales by a factor of 10)
-			}
-		}
-	}
-
-	return 0
-}
@@ -67,7 +67,8 @@ func RenderGridOffset(x, y int, grid [][]Runeable) error {
 	xo := x // Set the x offset
 	for _, row := range grid {
 		for _, c := range row {
-			termbox.SetCell(xo, y, c.Rune(), c.Fg(), c.Bg())
+			fg, bg := theme.Attributes(c)
+			termbox.SetCell(xo, y, c.Rune(), fg, bg)
 			xo += runewidth.RuneWidth(c.Rune())
 		}
 		xo = x // reset xoffest for next row
@@ -88,9 +89,16 @@ func RenderCell(x, y int, c rune, fg, bg termbox.Attribute) error {
 	return termbox.Flush()
 }
 
+// RenderCellAs renders a single cell in the style the theme gives to the category
+func RenderCellAs(x, y int, c rune, cat Category) error {
+	fg, bg := theme.Style(cat)
+	return RenderCell(x, y, c, fg, bg)
+}
+
 func RenderCells(c []Cell) error {
 	for _, ci := range c {
-		termbox.SetCell(ci.X(), ci.Y(), ci.Rune(), ci.Fg(), ci.Bg())
+		fg, bg := theme.Attributes(ci)
+		termbox.SetCell(ci.X(), ci.Y(), ci.Rune(), fg, bg)
 	}
 	return termbox.Flush()
 }
@@ -0,0 +1,238 @@
+package io
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	termbox "github.com/nsf/termbox-go"
+)
+
+// Category is the kind of object displayed in a cell, themes use it to pick the colors of the cell
+type Category string
+
+const (
+	Floor    Category = "floor"
+	Wall     Category = "wall"
+	Door     Category = "door"
+	Stairs   Category = "stairs"
+	Entrance Category = "entrance"
+	Feature  Category = "feature" // altars, thrones, fountains and other fixtures
+	Trap     Category = "trap"
+	Player   Category = "player"
+	Monster  Category = "monster"
+	Gold     Category = "gold"
+	Gem      Category = "gem"
+	Weapon   Category = "weapon"
+	Armor    Category = "armor"
+	Ring     Category = "ring"
+	Potion   Category = "potion"
+	Scroll   Category = "scroll"
+	Book     Category = "book"
+	Food     Category = "food"
+	Item     Category = "item" // any other item
+	Spell    Category = "spell"
+	Cursor   Category = "cursor" // the cell highlighted by a targeting cursor
+)
+
+// Categorized is implemented by displayed objects that belong to a category
+type Categorized interface {
+	Category() Category
+}
+
+// Named is implemented by displayed objects that a theme can style individually, such as a single kind of monster
+type Named interface {
+	Name() string
+}
+
+// colors maps the color names used in themes to termbox colors
+var colors = map[string]termbox.Attribute{
+	"":        termbox.ColorDefault,
+	"default": termbox.ColorDefault,
+	"black":   termbox.ColorBlack,
+	"red":     termbox.ColorRed,
+	"green":   termbox.ColorGreen,
+	"yellow":  termbox.ColorYellow,
+	"blue":    termbox.ColorBlue,
+	"magenta": termbox.ColorMagenta,
+	"cyan":    termbox.ColorCyan,
+	"white":   termbox.ColorWhite,
+}
+
+// Style is the colors and attributes a theme gives to a cell
+type Style struct {
+	Fg        string `json:"fg,omitempty"` // name of the foreground color
+	Bg        string `json:"bg,omitempty"` // name of the background color
+	Bold      bool   `json:"bold,omitempty"`
+	Underline bool   `json:"underline,omitempty"`
+	Reverse   bool   `json:"reverse,omitempty"`
+}
+
+// attributes returns the termbox foreground and background attributes of the style
+func (s Style) attributes() (termbox.Attribute, termbox.Attribute) {
+	fg, bg := colors[s.Fg], colors[s.Bg]
+	if s.Bold {
+		fg |= termbox.AttrBold
+	}
+	if s.Underline {
+		fg |= termbox.AttrUnderline
+	}
+	if s.Reverse {
+		fg |= termbox.AttrReverse
+	}
+	return fg, bg
+}
+
+// validate returns an error if the style uses an unknown color
+func (s Style) validate() error {
+	for _, c := range []string{s.Fg, s.Bg} {
+		if _, ok := colors[c]; !ok {
+			return fmt.Errorf("unknown color %q", c)
+		}
+	}
+	return nil
+}
+
+// Theme maps display categories and named objects to styles
+type Theme struct {
+	Categories map[Category]Style `json:"categories"`
+	Names      map[string]Style   `json:"names"` // styles for named objects, these override the style of their category
+}
+
+// Classic is the monochrome theme
+var Classic = &Theme{
+	Categories: map[Category]Style{
+		Player:   {Reverse: true},
+		Entrance: {Reverse: true},
+		Gold:     {Bold: true},
+		Gem:      {Bold: true},
+		Weapon:   {Bold: true},
+		Armor:    {Bold: true},
+		Ring:     {Bold: true},
+		Potion:   {Bold: true},
+		Scroll:   {Bold: true},
+		Book:     {Bold: true},
+		Food:     {Bold: true},
+		Item:     {Bold: true},
+		Cursor:   {Reverse: true},
+	},
+}
+
+// Colorful is the color theme
+var Colorful = &Theme{
+	Categories: map[Category]Style{
+		Wall:     {Fg: "white"},
+		Door:     {Fg: "yellow"},
+		Stairs:   {Fg: "white", Bold: true},
+		Entrance: {Fg: "black", Bg: "green"},
+		Feature:  {Fg: "cyan", Bold: true},
+		Trap:     {Fg: "magenta", Bold: true},
+		Player:   {Fg: "white", Bg: "red", Bold: true},
+		Monster:  {Fg: "red", Bold: true},
+		Gold:     {Fg: "yellow", Bold: true},
+		Gem:      {Fg: "cyan", Bold: true},
+		Weapon:   {Fg: "blue", Bold: true},
+		Armor:    {Fg: "blue", Bold: true},
+		Ring:     {Fg: "yellow"},
+		Potion:   {Fg: "magenta"},
+		Scroll:   {Fg: "white", Bold: true},
+		Book:     {Fg: "cyan"},
+		Food:     {Fg: "green"},
+		Item:     {Fg: "green", Bold: true},
+		Spell:    {Fg: "yellow", Bold: true},
+		Cursor:   {Fg: "black", Bg: "white"},
+	},
+	Names: map[string]Style{
+		"red dragon":      {Fg: "red", Bold: true, Underline: true},
+		"green dragon":    {Fg: "green", Bold: true, Underline: true},
+		"white dragon":    {Fg: "white", Bold: true, Underline: true},
+		"bronze dragon":   {Fg: "yellow", Underline: true},
+		"silver dragon":   {Fg: "white", Underline: true},
+		"platinum dragon": {Fg: "cyan", Bold: true, Underline: true},
+		"green urchin":    {Fg: "green", Bold: true},
+		"yellow mold":     {Fg: "yellow", Bold: true},
+		"violet fungi":    {Fg: "magenta", Bold: true},
+		"ice lizard":      {Fg: "cyan", Bold: true},
+		"hell hound":      {Fg: "yellow", Bold: true},
+	},
+}
+
+// Themes are the built in themes by name
+var Themes = map[string]*Theme{
+	"classic": Classic,
+	"color":   Colorful,
+}
+
+// theme is the theme used for rendering
+var theme = Classic
+
+// SetTheme sets the theme used for rendering
+func SetTheme(t *Theme) { theme = t }
+
+// LoadTheme returns the built in theme with the given name, or reads the theme from a json file at that path
+func LoadTheme(name string) (*Theme, error) {
+	if t, ok := Themes[name]; ok {
+		return t, nil
+	}
+
+	b, err := ioutil.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+
+	t := new(Theme)
+	if err := json.Unmarshal(b, t); err != nil {
+		return nil, err
+	}
+
+	for _, s := range t.Categories {
+		if err := s.validate(); err != nil {
+			return nil, err
+		}
+	}
+	for _, s := range t.Names {
+		if err := s.validate(); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+// fallbackStyles are used for categories a theme leaves out that must stand out to be usable
+var fallbackStyles = map[Category]Style{
+	Cursor: {Reverse: true},
+}
+
+// Style returns the foreground and background attributes the theme gives to the category
+func (t *Theme) Style(c Category) (termbox.Attribute, termbox.Attribute) {
+	s, ok := fallbackStyles[c]
+	if t != nil {
+		if ts, found := t.Categories[c]; found {
+			s, ok = ts, true
+		}
+	}
+	if !ok {
+		return termbox.ColorDefault, termbox.ColorDefault
+	}
+	return s.attributes()
+}
+
+// Attributes returns the foreground and background attributes to render r with. Objects without a category keep their own
+func (t *Theme) Attributes(r Runeable) (termbox.Attribute, termbox.Attribute) {
+	c, ok := r.(Categorized)
+	if t == nil || !ok {
+		return r.Fg(), r.Bg()
+	}
+
+	// Don't give away unseen objects
+	if r.Rune() == ' ' {
+		return termbox.ColorDefault, termbox.ColorDefault
+	}
+
+	if n, ok := r.(Named); ok {
+		if s, ok := t.Names[n.Name()]; ok {
+			return s.attributes()
+		}
+	}
+	return t.Style(c.Category())
+}
@@ -0,0 +1,86 @@
+package io
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	termbox "github.com/nsf/termbox-go"
+)
+
+type testCell struct {
+	r    rune
+	name string
+}
+
+func (c testCell) Rune() rune            { return c.r }
+func (c testCell) Fg() termbox.Attribute { return termbox.ColorBlue }
+func (c testCell) Bg() termbox.Attribute { return termbox.ColorBlue }
+func (c testCell) Category() Category    { return Monster }
+func (c testCell) Name() string          { return c.name }
+
+type plainCell rune
+
+func (c plainCell) Rune() rune            { return rune(c) }
+func (c plainCell) Fg() termbox.Attribute { return termbox.ColorCyan }
+func (c plainCell) Bg() termbox.Attribute { return termbox.ColorDefault }
+
+// TestThemeAttributes ensures named objects override their category and unseen objects aren't styled
+func TestThemeAttributes(t *testing.T) {
+	if fg, _ := Colorful.Attributes(testCell{r: 'B', name: "bat"}); fg != termbox.ColorRed|termbox.AttrBold {
+		t.Errorf("unexpected monster style %v", fg)
+	}
+
+	if fg, _ := Colorful.Attributes(testCell{r: 'D', name: "green dragon"}); fg != termbox.ColorGreen|termbox.AttrBold|termbox.AttrUnderline {
+		t.Errorf("named monster didn't override its category %v", fg)
+	}
+
+	if fg, bg := Colorful.Attributes(testCell{r: ' '}); fg != termbox.ColorDefault || bg != termbox.ColorDefault {
+		t.Error("unseen object was styled")
+	}
+
+	if fg, _ := Classic.Attributes(plainCell('a')); fg != termbox.ColorCyan {
+		t.Error("uncategorized object lost its own style")
+	}
+
+	if fg, _ := Classic.Style(Cursor); fg != termbox.AttrReverse {
+		t.Errorf("unexpected cursor style %v", fg)
+	}
+
+	if fg, _ := new(Theme).Style(Cursor); fg != termbox.AttrReverse {
+		t.Error("theme without a cursor style hid the cursor")
+	}
+}
+
+// TestLoadTheme ensures themes load by name or from a file and reject unknown colors
+func TestLoadTheme(t *testing.T) {
+	if th, err := LoadTheme("color"); err != nil || th != Colorful {
+		t.Fatal("unable to load built in theme", err)
+	}
+
+	dir, err := ioutil.TempDir("", "larn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "theme.json")
+	if err := ioutil.WriteFile(path, []byte(`{"categories": {"monster": {"fg": "green", "bold": true}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	th, err := LoadTheme(path)
+	if err != nil {
+		t.Fatal("unable to load theme file", err)
+	}
+	if fg, _ := th.Attributes(testCell{r: 'B'}); fg != termbox.ColorGreen|termbox.AttrBold {
+		t.Errorf("unexpected style from theme file %v", fg)
+	}
+
+	if err := ioutil.WriteFile(path, []byte(`{"categories": {"monster": {"fg": "mauve"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTheme(path); err == nil {
+		t.Error("theme with an unknown color loaded")
+	}
+}